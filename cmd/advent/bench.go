@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xorkevin/advent2021/internal/bench"
+	"github.com/xorkevin/advent2021/internal/cli"
+	"github.com/xorkevin/advent2021/internal/days"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+func cmdBench(args []string) error {
+	opts := bench.DefaultOptions()
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	part := fs.Int("part", 0, "only benchmark this part")
+	root := fs.String("root", ".", "repository root containing the day directories")
+	fs.IntVar(&opts.Warmup, "warmup", opts.Warmup, "untimed runs before measuring")
+	fs.IntVar(&opts.MinRuns, "runs", opts.MinRuns, "minimum number of timed runs")
+	fs.DurationVar(&opts.MinTime, "time", opts.MinTime, "minimum time spent measuring each day")
+	export := fs.String("export", "", "write results as JSON to this file")
+	baseline := fs.String("baseline", "", "compare results against a JSON file written by --export")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) > 1 {
+		return fmt.Errorf("%w: bench takes at most one day", cli.ErrUsage)
+	}
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("%w: part must be 1 or 2", cli.ErrUsage)
+	}
+
+	registry := days.Registry()
+	dayList := registry.Days()
+	if len(pos) == 1 && pos[0] != "all" {
+		day, err := parseDay(pos[0])
+		if err != nil {
+			return err
+		}
+		dayList = []int{day}
+	}
+
+	var base []bench.Result
+	if *baseline != "" {
+		base, err = bench.Load(*baseline)
+		if err != nil {
+			return err
+		}
+	}
+
+	bench.WriteHeader(os.Stdout)
+	results := make([]bench.Result, 0, len(dayList))
+	for _, i := range dayList {
+		r, err := benchDay(registry, opts, i, *part, defaultInput(*root, i))
+		if err != nil {
+			return err
+		}
+		bench.WriteRow(os.Stdout, r)
+		results = append(results, r)
+	}
+	bench.WriteTotals(os.Stdout, results)
+
+	if *export != "" {
+		if err := bench.Export(*export, results); err != nil {
+			return err
+		}
+	}
+	if base != nil {
+		fmt.Fprintln(os.Stdout)
+		bench.WriteComparisons(os.Stdout, bench.Compare(base, results))
+	}
+	return nil
+}
+
+func benchDay(registry *solver.Registry, opts bench.Options, day, part int, name string) (bench.Result, error) {
+	s, ok := registry.Get(day)
+	if !ok {
+		return bench.Result{}, fmt.Errorf("%w %d", ErrNoSolver, day)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return bench.Result{}, err
+	}
+	run := func() error {
+		_, _, err := s.Solve(bytes.NewReader(data))
+		return err
+	}
+	if part != 0 {
+		run = func() error {
+			_, err := s.SolvePart(part, bytes.NewReader(data))
+			return err
+		}
+	}
+	times, err := bench.Measure(opts, run)
+	if err != nil {
+		return bench.Result{}, fmt.Errorf("day %d: %w", day, err)
+	}
+	return bench.NewResult("go", day, part, times), nil
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+	"github.com/xorkevin/advent2021/internal/cli"
+	"github.com/xorkevin/advent2021/internal/days"
+)
+
+func cmdCheck(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	root := fs.String("root", ".", "repository root containing the day directories")
+	manifest := fs.String("manifest", "", "answers manifest (defaults to <root>/"+answers.DefaultName+")")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) > 1 {
+		return fmt.Errorf("%w: check takes at most one day", cli.ErrUsage)
+	}
+	only := 0
+	if len(pos) == 1 && pos[0] != "all" {
+		only, err = parseDay(pos[0])
+		if err != nil {
+			return err
+		}
+	}
+	if *manifest == "" {
+		*manifest = filepath.Join(*root, answers.DefaultName)
+	}
+
+	m, err := answers.Load(*manifest)
+	if err != nil {
+		return err
+	}
+	registry := days.Registry()
+	failed := false
+	for _, e := range m.Entries() {
+		day, err := e.Day()
+		if err != nil {
+			return err
+		}
+		if only != 0 && day != only {
+			continue
+		}
+		name := e.Dir + "/" + e.Input
+		s, ok := registry.Get(day)
+		if !ok {
+			fmt.Fprintf(os.Stdout, "FAIL %s: %v %d\n", name, ErrNoSolver, day)
+			failed = true
+			continue
+		}
+		mismatches, err := answers.Check(s, *root, e)
+		if err != nil {
+			fmt.Fprintf(os.Stdout, "FAIL %s: %v\n", name, err)
+			failed = true
+			continue
+		}
+		if len(mismatches) != 0 {
+			for _, i := range mismatches {
+				fmt.Fprintf(os.Stdout, "FAIL %s: %s\n", name, i)
+			}
+			failed = true
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "ok   %s\n", name)
+	}
+	if failed {
+		return ErrFailed
+	}
+	return nil
+}
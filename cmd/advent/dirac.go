@@ -0,0 +1,76 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xorkevin/advent2021/day21"
+	"github.com/xorkevin/advent2021/internal/cli"
+	"github.com/xorkevin/advent2021/internal/input"
+)
+
+const (
+	diracFormatText = "text"
+	diracFormatJSON = "json"
+)
+
+func readStart(name string) (_ []int, retErr error) {
+	file, err := input.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	start, err := day21.ParseStart(file)
+	if err != nil {
+		return nil, input.WithFile(err, name)
+	}
+	return start, nil
+}
+
+func cmdDirac(args []string) error {
+	g := day21.QuantumGame
+	fs := flag.NewFlagSet("dirac", flag.ContinueOnError)
+	inputFile := fs.String("input", "", "starting positions in the puzzle input format, or - for stdin (defaults to <root>/day21/input.txt)")
+	root := fs.String("root", ".", "repository root containing the day directories")
+	format := fs.String("format", diracFormatText, "output format, one of text or json")
+	states := fs.Bool("states", false, "include the odds from every reachable state")
+	fs.IntVar(&g.BoardSize, "board", g.BoardSize, "number of spaces on the board")
+	fs.IntVar(&g.WinScore, "win", g.WinScore, "score at which a player wins")
+	fs.IntVar(&g.DieSides, "sides", g.DieSides, "number of sides of the die")
+	fs.IntVar(&g.Rolls, "rolls", g.Rolls, "number of rolls each turn")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 0 {
+		return fmt.Errorf("%w: dirac takes no arguments", cli.ErrUsage)
+	}
+	if *format != diracFormatText && *format != diracFormatJSON {
+		return fmt.Errorf("%w: format must be text or json", cli.ErrUsage)
+	}
+	name := *inputFile
+	if name == "" {
+		name = defaultInput(*root, 21)
+	}
+	start, err := readStart(name)
+	if err != nil {
+		return err
+	}
+	g.Players = len(start)
+	a, err := g.Analyze(start, *states)
+	if err != nil {
+		return err
+	}
+	if *format == diracFormatJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(a)
+	}
+	return a.WriteTable(os.Stdout)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xorkevin/advent2021/internal/cli"
+	"github.com/xorkevin/advent2021/internal/fetch"
+)
+
+const (
+	sessionEnv = "ADVENT_SESSION"
+)
+
+func cmdFetch(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	root := fs.String("root", ".", "repository root containing the day directories")
+	session := fs.String("session", "", "session cookie token (defaults to $"+sessionEnv+")")
+	refresh := fs.Bool("refresh", false, "download files again even if they are cached")
+	baseURL := fs.String("base-url", fetch.DefaultBaseURL, "advent of code site")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: fetch requires exactly one day", cli.ErrUsage)
+	}
+	day, err := parseDay(pos[0])
+	if err != nil {
+		return err
+	}
+	if *session == "" {
+		*session = os.Getenv(sessionEnv)
+	}
+	if *session == "" {
+		return fmt.Errorf("%w: set --session or $%s", fetch.ErrNoSession, sessionEnv)
+	}
+
+	f := fetch.NewFetcher(*session)
+	f.BaseURL = *baseURL
+	updates, err := fetch.Day(context.Background(), f, fetch.Cache{Root: *root}, day, *refresh)
+	if err != nil {
+		return err
+	}
+	for _, i := range updates {
+		if i.Fetched {
+			fmt.Fprintf(os.Stdout, "fetched %s\n", i.Name)
+		} else {
+			fmt.Fprintf(os.Stdout, "cached  %s\n", i.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/cli"
+)
+
+var (
+	// ErrInvalidDay is a day which is not a number from 1 to 25
+	ErrInvalidDay = errors.New("Invalid day")
+)
+
+var app = cli.App{
+	Name: "advent",
+	Commands: []cli.Command{
+		{
+			Name:  "run",
+			Usage: "run <day|all> [--part 1|2] [--input file] [--root dir] [--format text|json|tsv] [--baseline file] [--jobs n] [--timeout d]",
+			Run:   cmdRun,
+		},
+		{
+			Name:  "check",
+			Usage: "check [day|all] [--root dir] [--manifest file]",
+			Run:   cmdCheck,
+		},
+		{
+			Name:  "bench",
+			Usage: "bench [day|all] [--part 1|2] [--root dir] [--warmup n] [--runs n] [--time d] [--export file] [--baseline file]",
+			Run:   cmdBench,
+		},
+		{
+			Name:  "parity",
+			Usage: "parity [day|all] [--root dir] [--go-bin file] [--target-dir dir] [--no-build]",
+			Run:   cmdParity,
+		},
+		{
+			Name:  "fetch",
+			Usage: "fetch <day> [--root dir] [--session token] [--refresh] [--base-url url]",
+			Run:   cmdFetch,
+		},
+		{
+			Name:  "new",
+			Usage: "new <day> [--root dir]",
+			Run:   cmdNew,
+		},
+		{
+			Name:  "dirac",
+			Usage: "dirac [--input file] [--root dir] [--format text|json] [--states] [--board n] [--win n] [--sides n] [--rolls n]",
+			Run:   cmdDirac,
+		},
+	},
+}
+
+func main() {
+	app.Main()
+}
+
+// parseDay parses a day given as "14", "day14", or "day 14"
+func parseDay(s string) (int, error) {
+	s = strings.TrimSpace(strings.TrimPrefix(strings.ToLower(s), "day"))
+	day, err := strconv.Atoi(s)
+	if err != nil || day < 1 || day > 25 {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidDay, s)
+	}
+	return day, nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xorkevin/advent2021/internal/cli"
+	"github.com/xorkevin/advent2021/internal/scaffold"
+)
+
+func cmdNew(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	root := fs.String("root", ".", "repository root containing the day directories")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: new requires exactly one day", cli.ErrUsage)
+	}
+	day, err := parseDay(pos[0])
+	if err != nil {
+		return err
+	}
+	written, err := scaffold.New(*root, day)
+	if err != nil {
+		return err
+	}
+	for _, i := range written {
+		fmt.Fprintf(os.Stdout, "wrote %s\n", i)
+	}
+	return nil
+}
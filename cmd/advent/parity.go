@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+	"github.com/xorkevin/advent2021/internal/cli"
+	"github.com/xorkevin/advent2021/internal/parity"
+)
+
+func cmdParity(args []string) error {
+	fs := flag.NewFlagSet("parity", flag.ContinueOnError)
+	root := fs.String("root", ".", "repository root containing the day directories")
+	goBin := fs.String("go-bin", "", "advent binary (defaults to <root>/bin/advent)")
+	targetDir := fs.String("target-dir", "", "cargo target directory (defaults to each crate's target directory)")
+	noBuild := fs.Bool("no-build", false, "use existing binaries instead of building them")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) > 1 {
+		return fmt.Errorf("%w: parity takes at most one day", cli.ErrUsage)
+	}
+	if *goBin == "" {
+		*goBin = filepath.Join(*root, "bin", "advent")
+	}
+
+	dayList, err := parity.RustDays(*root)
+	if err != nil {
+		return err
+	}
+	if len(pos) == 1 && pos[0] != "all" {
+		day, err := parseDay(pos[0])
+		if err != nil {
+			return err
+		}
+		dayList = []int{day}
+	}
+
+	ctx := context.Background()
+	goProg := parity.Go{Bin: *goBin}
+	rsProg := parity.Rust{TargetDir: *targetDir, Root: *root}
+	if !*noBuild {
+		if err := parity.BuildGo(ctx, *root, *goBin); err != nil {
+			return err
+		}
+	}
+
+	failed := false
+	for _, i := range dayList {
+		if !*noBuild {
+			if err := rsProg.Build(ctx, i); err != nil {
+				fmt.Fprintf(os.Stdout, "FAIL %s: %v\n", answers.DirName(i), err)
+				failed = true
+				continue
+			}
+		}
+		mismatches, failures, err := parity.Compare(ctx, goProg, rsProg, *root, i)
+		if err != nil {
+			return err
+		}
+		for _, j := range failures {
+			fmt.Fprintf(os.Stdout, "FAIL %s\n", j)
+		}
+		for _, j := range mismatches {
+			fmt.Fprintf(os.Stdout, "FAIL %s\n", j)
+		}
+		if len(failures) != 0 || len(mismatches) != 0 {
+			failed = true
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "ok   %s\n", answers.DirName(i))
+	}
+	if failed {
+		return ErrFailed
+	}
+	return nil
+}
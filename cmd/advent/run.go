@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+	"github.com/xorkevin/advent2021/internal/cli"
+	"github.com/xorkevin/advent2021/internal/days"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/result"
+	"github.com/xorkevin/advent2021/internal/runner"
+)
+
+var (
+	// ErrNoSolver is a day without a registered solver
+	ErrNoSolver = runner.ErrNoSolver
+	// ErrFailed is returned after every day is run if any of them failed
+	ErrFailed = errors.New("Some days failed")
+)
+
+func cmdRun(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	part := fs.Int("part", 0, "only print the answer to this part")
+	inputFile := fs.String("input", "", "puzzle input file, or - for stdin (defaults to <root>/dayNN/input.txt)")
+	root := fs.String("root", ".", "repository root containing the day directories")
+	format := fs.String("format", result.FormatText, "output format, one of text, json, or tsv")
+	baseline := fs.String("baseline", "", "compare time and memory against results written by --format json")
+	jobs := fs.Int("jobs", 0, "number of days solved at once, where memory is only measured with 1 (defaults to 1 with --format json or tsv or --baseline, and otherwise the number of CPUs)")
+	timeout := fs.Duration("timeout", 0, "time allowed to solve each day, or 0 for no limit")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: run requires exactly one day", cli.ErrUsage)
+	}
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("%w: part must be 1 or 2", cli.ErrUsage)
+	}
+
+	out, err := result.NewWriter(os.Stdout, *format)
+	if err != nil {
+		return fmt.Errorf("%w: %v", cli.ErrUsage, err)
+	}
+	var base []result.Result
+	if *baseline != "" {
+		base, err = result.Load(*baseline)
+		if err != nil {
+			return err
+		}
+	}
+
+	registry := days.Registry()
+
+	var jobList []runner.Job
+	opts := runner.Options{
+		Workers: *jobs,
+		Timeout: *timeout,
+	}
+	if opts.Workers == 0 && (*format != result.FormatText || *baseline != "") {
+		// memory is written or compared, so it must be measured
+		opts.Workers = 1
+	}
+	if pos[0] == "all" {
+		if *inputFile != "" {
+			return fmt.Errorf("%w: --input may not be used with all", cli.ErrUsage)
+		}
+		for _, i := range registry.Days() {
+			jobList = append(jobList, runner.Job{
+				Day:   i,
+				Part:  *part,
+				Input: defaultInput(*root, i),
+			})
+		}
+	} else {
+		day, err := parseDay(pos[0])
+		if err != nil {
+			return err
+		}
+		name := *inputFile
+		if name == "" {
+			name = defaultInput(*root, day)
+		}
+		jobList = []runner.Job{{
+			Day:   day,
+			Part:  *part,
+			Input: name,
+		}}
+		opts.Workers = 1
+	}
+
+	var results []result.Result
+	var errs []error
+	runner.Run(context.Background(), registry, jobList, opts, func(o runner.Outcome) {
+		for _, i := range o.Results {
+			if err := out.Write(i); err != nil {
+				errs = append(errs, err)
+				return
+			}
+			results = append(results, i)
+		}
+		if o.Err != nil {
+			if len(jobList) == 1 {
+				errs = append(errs, o.Err)
+				return
+			}
+			fmt.Fprintf(os.Stderr, "%s: %v\n", answers.DirName(o.Job.Day), o.Err)
+			errs = append(errs, ErrFailed)
+		}
+	})
+	if base != nil {
+		if err := result.WriteComparisons(os.Stderr, result.Compare(base, results)); err != nil {
+			return err
+		}
+	}
+	if len(errs) != 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func defaultInput(root string, day int) string {
+	return filepath.Join(root, answers.DirName(day), input.DefaultName)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xorkevin/advent2021/internal/alu"
+	"github.com/xorkevin/advent2021/internal/cli"
+)
+
+func cmdAnalyze(args []string) error {
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	inputMin := fs.Int("min", 1, "least value of an input")
+	inputMax := fs.Int("max", 9, "greatest value of an input")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: analyze requires exactly one program", cli.ErrUsage)
+	}
+	if *inputMin > *inputMax {
+		return fmt.Errorf("%w: min may not be greater than max", cli.ErrUsage)
+	}
+	prog, err := alu.ParseFile(pos[0])
+	if err != nil {
+		return err
+	}
+	return alu.Analyze(prog, *inputMin, *inputMax).WriteReport(os.Stdout)
+}
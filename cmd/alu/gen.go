@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xorkevin/advent2021/internal/alu"
+	"github.com/xorkevin/advent2021/internal/cli"
+)
+
+func cmdGen(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	pkg := fs.String("package", "main", "package of the generated file")
+	fn := fs.String("func", "run", "name of the generated function")
+	fold := fs.Bool("fold", false, "evaluate instructions whose args are known when generating")
+	dse := fs.Bool("dse", false, "remove instructions whose results are never read")
+	output := fs.String("o", "", "output file (defaults to stdout)")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: gen requires exactly one program", cli.ErrUsage)
+	}
+	prog, err := alu.ParseFile(pos[0])
+	if err != nil {
+		return err
+	}
+	src, err := alu.Generate(prog, alu.GenOptions{
+		Package: *pkg,
+		Func:    *fn,
+		Fold:    *fold,
+		DSE:     *dse,
+	})
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err := os.Stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*output, src, 0644)
+}
@@ -0,0 +1,84 @@
+// Command alu runs and inspects programs for the arithmetic logic unit of day
+// 24
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/cli"
+)
+
+var (
+	// ErrInvalidInput is an input or instruction number which is not a valid int
+	ErrInvalidInput = errors.New("Invalid input")
+)
+
+var app = cli.App{
+	Name: "alu",
+	Commands: []cli.Command{
+		{
+			Name:  "run",
+			Usage: "run <prog> [--input n,n,...]",
+			Run:   cmdRun,
+		},
+		{
+			Name:  "fmt",
+			Usage: "fmt <prog>",
+			Run:   cmdFmt,
+		},
+		{
+			Name:  "gen",
+			Usage: "gen <prog> [--package name] [--func name] [--fold] [--dse] [-o file]",
+			Run:   cmdGen,
+		},
+		{
+			Name:  "analyze",
+			Usage: "analyze <prog> [--min n] [--max n]",
+			Run:   cmdAnalyze,
+		},
+		{
+			Name:  "opt",
+			Usage: "opt <prog> [--passes name,...] [--live regs] [--check n] [--min n] [--max n] [-o file]",
+			Run:   cmdOpt,
+		},
+		{
+			Name:  "search",
+			Usage: "search <prog> [--smallest] [--min n] [--max n] [--workers n] [--timeout d]",
+			Run:   cmdSearch,
+		},
+		{
+			Name:  "trace",
+			Usage: "trace <prog> [--input n,n,...] [--summary] [--csv]",
+			Run:   cmdTrace,
+		},
+		{
+			Name:  "debug",
+			Usage: "debug <prog> [--input n,n,...] [--break n,n,...] [--break-inp]",
+			Run:   cmdDebug,
+		},
+	},
+	Footer: "A prog of - reads the program from stdin.",
+}
+
+func main() {
+	app.Main()
+}
+
+// parseInputs parses comma separated ints
+func parseInputs(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var stdin []int
+	for _, i := range strings.Split(s, ",") {
+		k, err := strconv.Atoi(strings.TrimSpace(i))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidInput, i)
+		}
+		stdin = append(stdin, k)
+	}
+	return stdin, nil
+}
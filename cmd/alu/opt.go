@@ -0,0 +1,72 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/alu"
+	"github.com/xorkevin/advent2021/internal/cli"
+)
+
+func cmdOpt(args []string) error {
+	fs := flag.NewFlagSet("opt", flag.ContinueOnError)
+	passNames := fs.String("passes", "zero,constprop,fuse,dse", "comma separated passes to run")
+	liveRegs := fs.String("live", "wxyz", "registers whose final values must be kept")
+	check := fs.Int("check", 1000, "number of random inputs on which to check the optimized program against the original, or 0 to skip")
+	inputMin := fs.Int("min", 1, "least value of a random input")
+	inputMax := fs.Int("max", 9, "greatest value of a random input")
+	output := fs.String("o", "", "output file (defaults to stdout)")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: opt requires exactly one program", cli.ErrUsage)
+	}
+	passes, err := alu.LookupPasses(strings.Split(*passNames, ","))
+	if err != nil {
+		return fmt.Errorf("%w: %v", cli.ErrUsage, err)
+	}
+	live, err := alu.ParseLive(*liveRegs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", cli.ErrUsage, err)
+	}
+	if *inputMin > *inputMax {
+		return fmt.Errorf("%w: min may not be greater than max", cli.ErrUsage)
+	}
+	prog, err := alu.ParseFile(pos[0])
+	if err != nil {
+		return err
+	}
+
+	opt, stats := alu.Optimize(prog, passes, live)
+	for _, i := range stats {
+		fmt.Fprintf(os.Stderr, "%-10s %6d -> %d\n", i.Name, i.Before, i.After)
+	}
+	fmt.Fprintf(os.Stderr, "%d instructions before, %d after\n", len(prog), len(opt))
+
+	if *check > 0 {
+		rng := rand.New(rand.NewSource(0))
+		stdins := make([][]int, *check)
+		for n := range stdins {
+			stdin := make([]int, prog.Inputs())
+			for i := range stdin {
+				stdin[i] = *inputMin + rng.Intn(*inputMax-*inputMin+1)
+			}
+			stdins[n] = stdin
+		}
+		if err := alu.Equivalent(prog, opt, live, stdins); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "equivalent on %d random inputs\n", *check)
+	}
+
+	if *output == "" {
+		_, err := os.Stdout.WriteString(opt.String())
+		return err
+	}
+	return os.WriteFile(*output, []byte(opt.String()), 0644)
+}
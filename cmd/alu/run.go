@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/xorkevin/advent2021/internal/alu"
+	"github.com/xorkevin/advent2021/internal/cli"
+)
+
+func cmdRun(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	inputs := fs.String("input", "", "comma separated inputs read by inp in order")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: run requires exactly one program", cli.ErrUsage)
+	}
+	stdin, err := parseInputs(*inputs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", cli.ErrUsage, err)
+	}
+	prog, err := alu.ParseFile(pos[0])
+	if err != nil {
+		return err
+	}
+	m := alu.NewMachine(stdin)
+	if err := m.Run(prog); err != nil {
+		// the registers are left as they were before the failing instruction
+		fmt.Fprintln(os.Stderr, "registers at failure:", formatRegs(m.Regs()))
+		return err
+	}
+	fmt.Fprintln(os.Stdout, formatRegs(m.Regs()))
+	if k := len(stdin) - m.InputsRead(); k > 0 {
+		fmt.Fprintf(os.Stderr, "%d inputs unread\n", k)
+	}
+	return nil
+}
+
+func cmdFmt(args []string) error {
+	fs := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: fmt requires exactly one program", cli.ErrUsage)
+	}
+	prog, err := alu.ParseFile(pos[0])
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.WriteString(prog.String())
+	return err
+}
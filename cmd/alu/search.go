@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/xorkevin/advent2021/day24"
+	"github.com/xorkevin/advent2021/internal/alu"
+	"github.com/xorkevin/advent2021/internal/cli"
+)
+
+func cmdSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	smallest := fs.Bool("smallest", false, "find the smallest inputs rather than the largest")
+	inputMin := fs.Int("min", 1, "least value of an input")
+	inputMax := fs.Int("max", 9, "greatest value of an input")
+	workers := fs.Int("workers", 0, "number of goroutines searching at once, or 0 for the number of CPUs")
+	timeout := fs.Duration("timeout", 0, "time allowed for the search, or 0 for no limit")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: search requires exactly one program", cli.ErrUsage)
+	}
+	if *inputMin > *inputMax {
+		return fmt.Errorf("%w: min may not be greater than max", cli.ErrUsage)
+	}
+	prog, err := alu.ParseFile(pos[0])
+	if err != nil {
+		return err
+	}
+	opts := alu.SearchOptions{
+		Largest: !*smallest,
+		Min:     *inputMin,
+		Max:     *inputMax,
+		Workers: *workers,
+	}
+	if monad, err := day24.ParseMonad(prog); err == nil {
+		opts.Prune = monad.Prune(*inputMin)
+	}
+	ctx := context.Background()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	stdin, stats, err := alu.Search(ctx, prog, opts)
+	fmt.Fprintf(os.Stderr, "ran %d blocks, memoized %d dead states, pruned %t, in %s\n", stats.Blocks, stats.Dead, opts.Prune != nil, time.Since(start).Round(time.Millisecond))
+	if err != nil {
+		return err
+	}
+	s := make([]string, 0, len(stdin))
+	for _, i := range stdin {
+		s = append(s, fmt.Sprint(i))
+	}
+	fmt.Fprintln(os.Stdout, strings.Join(s, ","))
+	return nil
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/alu"
+	"github.com/xorkevin/advent2021/internal/cli"
+)
+
+func formatRegs(regs [alu.NumRegs]int) string {
+	s := make([]string, 0, len(regs))
+	for n, i := range regs {
+		s = append(s, fmt.Sprintf("%s=%d", alu.RegName(n), i))
+	}
+	return strings.Join(s, " ")
+}
+
+func writeStep(w io.Writer, s alu.Step) error {
+	_, err := fmt.Fprintf(w, "%4d  %-12s %s\n", s.Index+1, s.Instr.String(), formatRegs(s.Regs))
+	return err
+}
+
+func writeSummary(w io.Writer, sums []alu.DigitSummary) error {
+	for _, i := range sums {
+		if _, err := fmt.Fprintf(w, "input %d = %d (instruction %d): z %d -> %d\n", i.Input+1, i.Value, i.Index+1, i.ZIn, i.ZOut); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func cmdTrace(args []string) error {
+	fs := flag.NewFlagSet("trace", flag.ContinueOnError)
+	inputs := fs.String("input", "", "comma separated inputs read by inp in order")
+	summary := fs.Bool("summary", false, "print z before and after each input instead of every instruction")
+	asCSV := fs.Bool("csv", false, "print as csv")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: trace requires exactly one program", cli.ErrUsage)
+	}
+	stdin, err := parseInputs(*inputs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", cli.ErrUsage, err)
+	}
+	prog, err := alu.ParseFile(pos[0])
+	if err != nil {
+		return err
+	}
+	trace, runErr := alu.TraceProgram(prog, stdin)
+	w := bufio.NewWriter(os.Stdout)
+	switch {
+	case *summary && *asCSV:
+		err = alu.WriteSummaryCSV(w, alu.SummarizeDigits(trace))
+	case *summary:
+		err = writeSummary(w, alu.SummarizeDigits(trace))
+	case *asCSV:
+		err = alu.WriteTraceCSV(w, trace)
+	default:
+		for _, i := range trace {
+			if err = writeStep(w, i); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return runErr
+}
+
+const debugHelp = `Commands:
+  s, step [n]       run n instructions, default 1
+  c, continue       run until a breakpoint or the program halts
+  b, break <n|inp>  break before the 1-indexed instruction, or every inp
+  d, delete <n|inp> remove a breakpoint
+  r, regs           print the registers
+  l, list           print the next instruction
+  summary           print z before and after each input read so far
+  q, quit           exit`
+
+func cmdDebug(args []string) error {
+	fs := flag.NewFlagSet("debug", flag.ContinueOnError)
+	inputs := fs.String("input", "", "comma separated inputs read by inp in order")
+	breaks := fs.String("break", "", "comma separated 1-indexed instructions to break before")
+	breakInp := fs.Bool("break-inp", false, "break before every inp")
+	pos, err := cli.ParseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return fmt.Errorf("%w: debug requires exactly one program", cli.ErrUsage)
+	}
+	if pos[0] == "-" {
+		return fmt.Errorf("%w: debug reads commands from stdin", cli.ErrUsage)
+	}
+	stdin, err := parseInputs(*inputs)
+	if err != nil {
+		return fmt.Errorf("%w: %v", cli.ErrUsage, err)
+	}
+	breakAt, err := parseInputs(*breaks)
+	if err != nil {
+		return fmt.Errorf("%w: %v", cli.ErrUsage, err)
+	}
+	prog, err := alu.ParseFile(pos[0])
+	if err != nil {
+		return err
+	}
+	d := alu.NewDebugger(prog, stdin)
+	for _, i := range breakAt {
+		d.Break(i - 1)
+	}
+	d.BreakOnInp(*breakInp)
+	return debugLoop(d, prog, os.Stdin, os.Stdout)
+}
+
+func setBreak(d *alu.Debugger, arg string, on bool) error {
+	if arg == "inp" {
+		d.BreakOnInp(on)
+		return nil
+	}
+	k, err := strconv.Atoi(arg)
+	if err != nil || k < 1 {
+		return fmt.Errorf("%w: %q", ErrInvalidInput, arg)
+	}
+	if on {
+		d.Break(k - 1)
+	} else {
+		d.Clear(k - 1)
+	}
+	return nil
+}
+
+func debugLoop(d *alu.Debugger, prog alu.Program, r io.Reader, w io.Writer) error {
+	printNext := func() {
+		if err := d.Err(); err != nil {
+			fmt.Fprintln(w, err)
+			return
+		}
+		if d.Done() {
+			fmt.Fprintln(w, "halted:", formatRegs(d.Regs()))
+			return
+		}
+		fmt.Fprintf(w, "%4d  %s\n", d.PC()+1, prog[d.PC()])
+	}
+	printNext()
+	scanner := bufio.NewScanner(r)
+	for {
+		fmt.Fprint(w, "(alu) ")
+		if !scanner.Scan() {
+			fmt.Fprintln(w)
+			return scanner.Err()
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		arg := ""
+		if len(fields) > 1 {
+			arg = fields[1]
+		}
+		switch fields[0] {
+		case "s", "step":
+			k := 1
+			if arg != "" {
+				var err error
+				if k, err = strconv.Atoi(arg); err != nil || k < 1 {
+					fmt.Fprintf(w, "%v: %q\n", ErrInvalidInput, arg)
+					continue
+				}
+			}
+			for ; k > 0; k-- {
+				// a halted or crashed program is reported by printNext
+				s, err := d.Step()
+				if err != nil {
+					break
+				}
+				writeStep(w, s)
+			}
+			printNext()
+		case "c", "continue":
+			if !d.Done() {
+				d.Continue()
+			}
+			printNext()
+		case "b", "break", "d", "delete":
+			if err := setBreak(d, arg, fields[0][0] == 'b'); err != nil {
+				fmt.Fprintln(w, err)
+			}
+		case "r", "regs":
+			fmt.Fprintln(w, formatRegs(d.Regs()))
+		case "l", "list":
+			printNext()
+		case "summary":
+			writeSummary(w, alu.SummarizeDigits(d.Trace))
+		case "q", "quit":
+			return nil
+		default:
+			fmt.Fprintln(w, debugHelp)
+		}
+	}
+}
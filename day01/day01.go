@@ -0,0 +1,65 @@
+package day01
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+func parse(r io.Reader) ([]int, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	nums := make([]int, 0, len(lines))
+	for _, line := range lines {
+		num, err := strconv.Atoi(line)
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, num)
+	}
+	return nums, nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	nums, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	prev := 0
+	count := -1
+	for _, num := range nums {
+		if num > prev {
+			count++
+		}
+		prev = num
+	}
+	return solver.Int(count), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	nums, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	prev := 0
+	prev1 := 0
+	prevsum := 0
+	count2 := -3
+	for _, num := range nums {
+		k := num + prev + prev1
+		if k > prevsum {
+			count2++
+		}
+		prevsum = k
+		prev1 = prev
+		prev = num
+	}
+	return solver.Int(count2), nil
+}
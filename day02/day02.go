@@ -0,0 +1,93 @@
+package day02
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidLine = errors.New("Invalid line format")
+)
+
+const (
+	lineFormat = "<forward|down|up> <int>"
+)
+
+type (
+	Command struct {
+		dir string
+		num int
+	}
+)
+
+func parse(r io.Reader) ([]Command, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cmds := make([]Command, 0, len(lines))
+	for n, line := range lines {
+		arr := strings.SplitN(line, " ", 2)
+		if len(arr) < 2 {
+			return nil, input.NewParseError(n+1, 0, lineFormat, ErrInvalidLine)
+		}
+		num, err := input.Atoi(arr[1], n+1, len(arr[0])+2, lineFormat)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, Command{
+			dir: arr[0],
+			num: num,
+		})
+	}
+	return cmds, nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	cmds, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	pos := 0
+	depth := 0
+	for _, i := range cmds {
+		switch i.dir {
+		case "forward":
+			pos += i.num
+		case "down":
+			depth += i.num
+		case "up":
+			depth -= i.num
+		}
+	}
+	return solver.Int(pos * depth), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	cmds, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	pos2 := 0
+	depth2 := 0
+	aim := 0
+	for _, i := range cmds {
+		switch i.dir {
+		case "forward":
+			pos2 += i.num
+			depth2 += aim * i.num
+		case "down":
+			aim += i.num
+		case "up":
+			aim -= i.num
+		}
+	}
+	return solver.Int(pos2 * depth2), nil
+}
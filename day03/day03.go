@@ -1,39 +1,20 @@
-package main
+package day03
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
-)
+	"io"
 
-const (
-	puzzleInput = "input.txt"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
 )
 
-func main() {
-	file, err := os.Open(puzzleInput)
+func Part1(r io.Reader) (solver.Answer, error) {
+	nums, err := input.Grid(r)
 	if err != nil {
-		log.Fatal(err)
-	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
-
-	var nums [][]byte
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		nums = append(nums, []byte(scanner.Text()))
-	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		return solver.Answer{}, err
 	}
 
 	if len(nums) == 0 {
-		return
+		return solver.Answer{}, nil
 	}
 
 	numbits := len(nums[0])
@@ -59,7 +40,20 @@ func main() {
 			min[n] = '1'
 		}
 	}
-	fmt.Println("Part 1:", btoi(max)*btoi(min))
+	return solver.Int(btoi(max) * btoi(min)), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	nums, err := input.Grid(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	if len(nums) == 0 {
+		return solver.Answer{}, nil
+	}
+
+	numbits := len(nums[0])
 
 	most := nums
 	least := nums
@@ -76,9 +70,10 @@ func main() {
 		}
 		least = findCommon(false, i, least)
 	}
-	if len(most) == 1 && len(least) == 1 {
-		fmt.Println("Part 2:", btoi(most[0])*btoi(least[0]))
+	if len(most) != 1 || len(least) != 1 {
+		return solver.Answer{}, nil
 	}
+	return solver.Int(btoi(most[0]) * btoi(least[0])), nil
 }
 
 func findCommon(most bool, pos int, nums [][]byte) [][]byte {
@@ -1,16 +1,20 @@
-package main
+package day04
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrDuplicateNum = errors.New("Duplicate num")
 )
 
 const (
-	puzzleInput = "input.txt"
+	numsFormat = "int[,int...]"
+	rowFormat  = "int int int int int"
 )
 
 type (
@@ -29,16 +33,11 @@ type (
 	}
 )
 
-func main() {
-	file, err := os.Open(puzzleInput)
+func parse(r io.Reader) ([]int, []*Board, error) {
+	lines, err := input.Lines(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, nil, err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
 
 	var nums []int
 	var boards []*Board
@@ -48,20 +47,19 @@ func main() {
 		Marked:   map[int]Pos{},
 	}
 	first := true
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
+	for lineno, line := range lines {
 		if first {
 			first = false
-			for _, i := range strings.Split(scanner.Text(), ",") {
-				num, err := strconv.Atoi(i)
+			fields, cols := input.Split(line, ",")
+			for n, i := range fields {
+				num, err := input.Atoi(i, lineno+1, cols[n], numsFormat)
 				if err != nil {
-					log.Fatal(err)
+					return nil, nil, err
 				}
 				nums = append(nums, num)
 			}
 			continue
 		}
-		line := scanner.Text()
 		if line == "" {
 			if board.Height > 0 {
 				board.Rows = make([]int, board.Height)
@@ -77,15 +75,15 @@ func main() {
 		}
 		h := board.Height
 		board.Height++
-		row := strings.Fields(line)
+		row, cols := input.Fields(line)
 		board.Width = len(row)
 		for n, i := range row {
-			num, err := strconv.Atoi(i)
+			num, err := input.Atoi(i, lineno+1, cols[n], rowFormat)
 			if err != nil {
-				log.Fatal(err)
+				return nil, nil, err
 			}
 			if _, ok := board.Unmarked[num]; ok {
-				log.Fatalln("Duplicate num")
+				return nil, nil, input.NewParseError(lineno+1, cols[n], rowFormat, ErrDuplicateNum)
 			}
 			board.Unmarked[num] = Pos{
 				X: n,
@@ -94,10 +92,6 @@ func main() {
 		}
 	}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
-
 	if board.Height > 0 {
 		board.Rows = make([]int, board.Height)
 		board.Cols = make([]int, board.Width)
@@ -105,8 +99,33 @@ func main() {
 		board = nil
 	}
 
+	return nums, boards, nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	nums, boards, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	for _, i := range nums {
+		for _, j := range boards {
+			k := markBoard(j, i)
+			if k > -1 {
+				return solver.Int(k * i), nil
+			}
+		}
+	}
+	return solver.Answer{}, nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	nums, boards, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
 	skipMap := map[int]struct{}{}
-	first = true
 	for _, i := range nums {
 		for n, j := range boards {
 			if _, ok := skipMap[n]; ok {
@@ -114,18 +133,14 @@ func main() {
 			}
 			k := markBoard(j, i)
 			if k > -1 {
-				if first {
-					first = false
-					fmt.Println("Part 1:", k*i)
-				}
 				skipMap[n] = struct{}{}
 				if len(skipMap) >= len(boards) {
-					fmt.Println("Part 2:", k*i)
-					return
+					return solver.Int(k * i), nil
 				}
 			}
 		}
 	}
+	return solver.Answer{}, nil
 }
 
 func markBoard(board *Board, num int) int {
@@ -0,0 +1,20 @@
+package day04
+
+import (
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/solver"
+	"github.com/xorkevin/advent2021/internal/solvertest"
+)
+
+func TestSolve(t *testing.T) {
+	solvertest.Manifest(t, solver.Parts{Part1: Part1, Part2: Part2})
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.Bench(b, Part1, "input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.Bench(b, Part2, "input.txt")
+}
@@ -0,0 +1,144 @@
+package day05
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidLine = errors.New("Invalid line")
+)
+
+const (
+	lineFormat = "x1,y1 -> x2,y2"
+)
+
+type (
+	Pos struct {
+		X int
+		Y int
+	}
+
+	Line struct {
+		A Pos
+		B Pos
+	}
+)
+
+func parse(r io.Reader) ([]Line, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	vents := make([]Line, 0, len(lines))
+	for n, line := range lines {
+		arr, cols := input.Split(line, " -> ")
+		if len(arr) != 2 {
+			return nil, input.NewParseError(n+1, 0, lineFormat, ErrInvalidLine)
+		}
+		var k [4]int
+		for j, i := range arr {
+			nums, numCols := input.Split(i, ",")
+			if len(nums) != 2 {
+				return nil, input.NewParseError(n+1, cols[j], lineFormat, ErrInvalidLine)
+			}
+			for l, num := range nums {
+				v, err := input.Atoi(num, n+1, cols[j]+numCols[l]-1, lineFormat)
+				if err != nil {
+					return nil, err
+				}
+				k[2*j+l] = v
+			}
+		}
+		vents = append(vents, Line{
+			A: Pos{X: k[0], Y: k[1]},
+			B: Pos{X: k[2], Y: k[3]},
+		})
+	}
+	return vents, nil
+}
+
+func countOverlaps(vents []Line, diagonals bool) int {
+	grid := map[Pos]int{}
+	for _, i := range vents {
+		x1, y1, x2, y2 := i.A.X, i.A.Y, i.B.X, i.B.Y
+		if x1 == x2 {
+			start, stop := minmax(y1, y2)
+			for j := start; j <= stop; j++ {
+				k := Pos{X: x1, Y: j}
+				if _, ok := grid[k]; !ok {
+					grid[k] = 0
+				}
+				grid[k]++
+			}
+		} else if y1 == y2 {
+			start, stop := minmax(x1, x2)
+			for j := start; j <= stop; j++ {
+				k := Pos{X: j, Y: y1}
+				if _, ok := grid[k]; !ok {
+					grid[k] = 0
+				}
+				grid[k]++
+			}
+		} else if diagonals {
+			start := Pos{X: x1, Y: y1}
+			stop := Pos{X: x2, Y: y2}
+			dirX := sign(x2 - x1)
+			dirY := sign(y2 - y1)
+			for start.X != stop.X {
+				if _, ok := grid[start]; !ok {
+					grid[start] = 0
+				}
+				grid[start]++
+				start.X += dirX
+				start.Y += dirY
+			}
+			if _, ok := grid[stop]; !ok {
+				grid[stop] = 0
+			}
+			grid[stop]++
+		}
+	}
+
+	count := 0
+	for _, v := range grid {
+		if v > 1 {
+			count++
+		}
+	}
+	return count
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	vents, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(countOverlaps(vents, false)), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	vents, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(countOverlaps(vents, true)), nil
+}
+
+func minmax(a, b int) (int, int) {
+	if a < b {
+		return a, b
+	}
+	return b, a
+}
+
+func sign(a int) int {
+	if a > 0 {
+		return 1
+	}
+	return -1
+}
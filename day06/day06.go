@@ -0,0 +1,50 @@
+package day06
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+func simulate(fish []int, days int) int {
+	nums := make([]int, 9)
+	for _, i := range fish {
+		nums[i]++
+	}
+
+	for k := 0; k < days; k++ {
+		next := make([]int, 9)
+		for n, i := range nums {
+			if n == 0 {
+				next[6] += i
+				next[8] += i
+			} else {
+				next[n-1] += i
+			}
+		}
+		nums = next
+	}
+
+	count := 0
+	for _, i := range nums {
+		count += i
+	}
+	return count
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	fish, err := input.Ints(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(simulate(fish, 80)), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	fish, err := input.Ints(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(simulate(fish, 256)), nil
+}
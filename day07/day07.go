@@ -0,0 +1,62 @@
+package day07
+
+import (
+	"io"
+	"math"
+	"sort"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	nums, err := input.Ints(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	sort.Ints(nums)
+	median := nums[len(nums)/2]
+	diffs := 0
+	for _, i := range nums {
+		diffs += abs(i, median)
+	}
+	return solver.Int(diffs), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	nums, err := input.Ints(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	sum := 0
+	for _, i := range nums {
+		sum += i
+	}
+	a1 := int(math.Floor(float64(sum) / float64(len(nums))))
+	a2 := int(math.Ceil(float64(sum) / float64(len(nums))))
+	diffs1 := 0
+	diffs2 := 0
+	for _, i := range nums {
+		k1 := abs(i, a1)
+		diffs1 += k1 * (k1 + 1) / 2
+		k2 := abs(i, a2)
+		diffs2 += k2 * (k2 + 1) / 2
+	}
+	return solver.Int(min(diffs1, diffs2)), nil
+}
+
+func abs(a, b int) int {
+	if a < b {
+		return b - a
+	}
+	return a - b
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,85 @@
+package day09
+
+import (
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/xorkevin/advent2021/internal/grid"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidHeight = errors.New("Invalid height")
+)
+
+const (
+	rowFormat = "digits 0-9"
+)
+
+func parse(r io.Reader) (*grid.Dense[byte], error) {
+	rows, err := input.Grid(r)
+	if err != nil {
+		return nil, err
+	}
+	return grid.Parse(rows, rowFormat, func(c byte) (byte, error) {
+		if c < '0' || c > '9' {
+			return 0, ErrInvalidHeight
+		}
+		return c - '0', nil
+	})
+}
+
+func isLow(g *grid.Dense[byte], p grid.Point) bool {
+	v := g.Get(p)
+	for _, i := range grid.Neighbors4[byte](g, p) {
+		if g.Get(i) <= v {
+			return false
+		}
+	}
+	return true
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	g, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	count := 0
+	g.Range(func(p grid.Point, v byte) {
+		if isLow(g, p) {
+			count += int(v) + 1
+		}
+	})
+	return solver.Int(count), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	g, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	basin := grid.NewDense[bool](g.Width(), g.Height())
+	var sizes []int
+	g.Range(func(p grid.Point, v byte) {
+		if !isLow(g, p) {
+			return
+		}
+		region := grid.FloodFill[byte](g, p, func(p grid.Point, v byte) bool {
+			return v != 9 && !basin.Get(p)
+		})
+		for _, i := range region {
+			basin.Set(i, true)
+		}
+		sizes = append(sizes, len(region))
+	})
+	if len(sizes) < 3 {
+		return solver.Answer{}, nil
+	}
+	sort.Ints(sizes)
+	l := len(sizes)
+	return solver.Int(sizes[l-1] * sizes[l-2] * sizes[l-3]), nil
+}
@@ -1,15 +1,11 @@
-package main
+package day10
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"io"
 	"sort"
-)
 
-const (
-	puzzleInput = "input.txt"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
 )
 
 type (
@@ -40,37 +36,42 @@ func (s *Stack) Peek() (byte, bool) {
 	return s.k[l-1], true
 }
 
-func main() {
-	file, err := os.Open(puzzleInput)
+func Part1(r io.Reader) (solver.Answer, error) {
+	lines, err := input.Lines(r)
 	if err != nil {
-		log.Fatal(err)
+		return solver.Answer{}, err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
 
 	score := 0
-	var completes []int
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		r, p, c := parsePair([]byte(scanner.Text()))
+	for _, line := range lines {
+		r, p, _ := parsePair([]byte(line))
 		if r == 2 {
 			score += p
-		} else if r == 1 {
-			completes = append(completes, c)
 		}
 	}
+	return solver.Int(score), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+	var completes []int
+	for _, line := range lines {
+		r, _, c := parsePair([]byte(line))
+		if r == 1 {
+			completes = append(completes, c)
+		}
 	}
 
 	sort.Ints(completes)
 
-	fmt.Println("Part 1:", score)
-	fmt.Println("Part 2:", completes[len(completes)/2])
+	if len(completes) == 0 {
+		return solver.Answer{}, nil
+	}
+	return solver.Int(completes[len(completes)/2]), nil
 }
 
 func parsePair(s []byte) (int, int, int) {
@@ -0,0 +1,112 @@
+package day11
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/grid"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidEnergy = errors.New("Invalid energy level")
+)
+
+const (
+	rowFormat = "digits 0-9"
+)
+
+type (
+	Grid struct {
+		g *grid.Dense[int]
+	}
+)
+
+func NewGrid(g *grid.Dense[int]) *Grid {
+	return &Grid{
+		g: g,
+	}
+}
+
+func (g *Grid) Step() int {
+	flashes := map[grid.Point]struct{}{}
+	var stack []grid.Point
+	g.g.Range(func(p grid.Point, v int) {
+		g.g.Set(p, v+1)
+		if v+1 > 9 {
+			flashes[p] = struct{}{}
+			stack = append(stack, p)
+		}
+	})
+	for len(stack) > 0 {
+		i := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, k := range grid.Neighbors8[int](g.g, i) {
+			if g.incr(k, flashes) {
+				flashes[k] = struct{}{}
+				stack = append(stack, k)
+			}
+		}
+	}
+	for i := range flashes {
+		g.g.Set(i, 0)
+	}
+	return len(flashes)
+}
+
+func (g *Grid) incr(p grid.Point, flashes map[grid.Point]struct{}) bool {
+	v := g.g.Get(p) + 1
+	g.g.Set(p, v)
+	if v > 9 {
+		_, ok := flashes[p]
+		return !ok
+	}
+	return false
+}
+
+func parse(r io.Reader) (*Grid, error) {
+	rows, err := input.Grid(r)
+	if err != nil {
+		return nil, err
+	}
+	g, err := grid.Parse(rows, rowFormat, func(c byte) (int, error) {
+		if c < '0' || c > '9' {
+			return 0, ErrInvalidEnergy
+		}
+		return int(c - '0'), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewGrid(g), nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	g, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	count := 0
+	for i := 0; i < 100; i++ {
+		count += g.Step()
+	}
+	return solver.Int(count), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	g, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	total := g.g.Width() * g.g.Height()
+	step := 0
+	for {
+		step++
+		if g.Step() == total {
+			return solver.Int(step), nil
+		}
+	}
+}
@@ -1,15 +1,20 @@
-package main
+package day12
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"errors"
+	"io"
 	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidLine = errors.New("Invalid line")
 )
 
 const (
-	puzzleInput = "input.txt"
+	lineFormat = "<cave>-<cave>"
 )
 
 type (
@@ -125,31 +130,35 @@ func (g *Graph) FindPath2(start, end string) int {
 	return g.findPath2(start, end, &path)
 }
 
-func main() {
-	file, err := os.Open(puzzleInput)
+func parse(r io.Reader) (*Graph, error) {
+	lines, err := input.Lines(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
 
 	graph := NewGraph()
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		arr := strings.SplitN(scanner.Text(), "-", 2)
+	for n, line := range lines {
+		arr := strings.SplitN(line, "-", 2)
 		if len(arr) != 2 {
-			log.Fatalln("Invalid line")
+			return nil, input.NewParseError(n+1, 0, lineFormat, ErrInvalidLine)
 		}
 		graph.AddEdge(arr[0], arr[1])
 	}
+	return graph, nil
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+func Part1(r io.Reader) (solver.Answer, error) {
+	graph, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
+	return solver.Int(graph.FindPath("start", "end")), nil
+}
 
-	fmt.Println("Part 1:", graph.FindPath("start", "end"))
-	fmt.Println("Part 2:", graph.FindPath2("start", "end"))
+func Part2(r io.Reader) (solver.Answer, error) {
+	graph, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(graph.FindPath2("start", "end")), nil
 }
@@ -0,0 +1,165 @@
+package day13
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidLine = errors.New("Invalid line")
+)
+
+const (
+	pointFormat = "x,y"
+	foldFormat  = "fold along <x|y>=int"
+)
+
+type (
+	Pos struct {
+		x int
+		y int
+	}
+
+	Fold struct {
+		yaxis   bool
+		axisval int
+	}
+)
+
+func parse(r io.Reader) (map[Pos]struct{}, []Fold, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	points := map[Pos]struct{}{}
+	var folds []Fold
+	trackPoints := true
+	for n, line := range lines {
+		if line == "" {
+			trackPoints = false
+			continue
+		}
+		if trackPoints {
+			arr := strings.SplitN(line, ",", 2)
+			if len(arr) != 2 {
+				return nil, nil, input.NewParseError(n+1, 0, pointFormat, ErrInvalidLine)
+			}
+			x, err := input.Atoi(arr[0], n+1, 1, pointFormat)
+			if err != nil {
+				return nil, nil, err
+			}
+			y, err := input.Atoi(arr[1], n+1, len(arr[0])+2, pointFormat)
+			if err != nil {
+				return nil, nil, err
+			}
+			points[Pos{
+				x: x,
+				y: y,
+			}] = struct{}{}
+			continue
+		}
+		words, cols := input.Fields(line)
+		if len(words) != 3 {
+			return nil, nil, input.NewParseError(n+1, 0, foldFormat, ErrInvalidLine)
+		}
+		arr := strings.SplitN(words[2], "=", 2)
+		if len(arr) != 2 || (arr[0] != "x" && arr[0] != "y") {
+			return nil, nil, input.NewParseError(n+1, cols[2], foldFormat, ErrInvalidLine)
+		}
+		axisval, err := input.Atoi(arr[1], n+1, cols[2]+2, foldFormat)
+		if err != nil {
+			return nil, nil, err
+		}
+		folds = append(folds, Fold{
+			yaxis:   arr[0] == "y",
+			axisval: axisval,
+		})
+	}
+	return points, folds, nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	points, folds, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	if len(folds) == 0 {
+		return solver.Answer{}, nil
+	}
+	points = fold(points, folds[0].yaxis, folds[0].axisval)
+	return solver.Int(len(points)), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	points, folds, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	for _, i := range folds {
+		points = fold(points, i.yaxis, i.axisval)
+	}
+
+	maxx, maxy := findMax(points)
+	grid := make([][]byte, maxy)
+	for i := range grid {
+		row := make([]byte, maxx)
+		for j := range row {
+			row[j] = ' '
+		}
+		grid[i] = row
+	}
+	for k := range points {
+		grid[k.y][k.x] = '#'
+	}
+
+	rows := make([]string, 0, len(grid))
+	for _, i := range grid {
+		rows = append(rows, string(i))
+	}
+	return solver.Grid(rows), nil
+}
+
+func fold(points map[Pos]struct{}, yaxis bool, axisval int) map[Pos]struct{} {
+	next := map[Pos]struct{}{}
+	for k := range points {
+		if yaxis {
+			if k.y <= axisval {
+				next[k] = struct{}{}
+				continue
+			}
+			next[Pos{
+				x: k.x,
+				y: axisval - (k.y - axisval),
+			}] = struct{}{}
+		} else {
+			if k.x <= axisval {
+				next[k] = struct{}{}
+				continue
+			}
+			next[Pos{
+				x: axisval - (k.x - axisval),
+				y: k.y,
+			}] = struct{}{}
+		}
+	}
+	return next
+}
+
+func findMax(points map[Pos]struct{}) (int, int) {
+	x := 0
+	y := 0
+	for k := range points {
+		if k.x > x {
+			x = k.x
+		}
+		if k.y > y {
+			y = k.y
+		}
+	}
+	return x + 1, y + 1
+}
@@ -1,35 +1,42 @@
-package main
+package day14
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"errors"
+	"io"
 	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidLine = errors.New("Invalid line")
 )
 
 const (
-	puzzleInput = "input.txt"
+	ruleFormat = "AB -> C"
 )
 
-func main() {
-	file, err := os.Open(puzzleInput)
+type (
+	Polymer struct {
+		first byte
+		last  byte
+		pairs map[string]int
+		rules map[string]byte
+	}
+)
+
+func parse(r io.Reader) (*Polymer, error) {
+	lines, err := input.Lines(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
 
 	var first, last byte
 	pairs := map[string]int{}
 	rules := map[string]byte{}
-	scanner := bufio.NewScanner(file)
 	batch1 := true
-	for scanner.Scan() {
-		line := scanner.Text()
+	for n, line := range lines {
 		if line == "" {
 			batch1 = false
 			continue
@@ -48,28 +55,47 @@ func main() {
 		}
 		arr := strings.SplitN(line, " -> ", 2)
 		if len(arr) != 2 {
-			log.Fatalln("Invalid line")
+			return nil, input.NewParseError(n+1, 0, ruleFormat, ErrInvalidLine)
+		}
+		if len(arr[0]) != 2 {
+			return nil, input.NewParseError(n+1, 1, ruleFormat, ErrInvalidLine)
 		}
 		if len(arr[1]) != 1 {
-			log.Fatalln("Invalid line")
+			return nil, input.NewParseError(n+1, len(arr[0])+5, ruleFormat, ErrInvalidLine)
 		}
 		rules[arr[0]] = arr[1][0]
 	}
+	return &Polymer{
+		first: first,
+		last:  last,
+		pairs: pairs,
+		rules: rules,
+	}, nil
+}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+func (p *Polymer) Diff(steps int) int {
+	pairs := p.pairs
+	for i := 0; i < steps; i++ {
+		pairs = processStep(p.rules, pairs)
 	}
+	max, min := maxminCount(pairs, p.first, p.last)
+	return max - min
+}
 
-	for i := 0; i < 10; i++ {
-		pairs = processStep(rules, pairs)
+func Part1(r io.Reader) (solver.Answer, error) {
+	p, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
-	max, min := maxminCount(pairs, first, last)
-	fmt.Println("Part 1:", max-min)
-	for i := 0; i < 30; i++ {
-		pairs = processStep(rules, pairs)
+	return solver.Int(p.Diff(10)), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	p, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
-	max, min = maxminCount(pairs, first, last)
-	fmt.Println("Part 2:", max-min)
+	return solver.Int(p.Diff(40)), nil
 }
 
 func processStep(rules map[string]byte, pairs map[string]int) map[string]int {
@@ -0,0 +1,75 @@
+package day15
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/grid"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/search"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidRisk = errors.New("Invalid risk level")
+)
+
+const (
+	rowFormat = "digits 1-9"
+)
+
+func pathfind(g *grid.Dense[int], start, end grid.Point) int {
+	graph := search.Graph[grid.Point]{
+		Neighbors: func(p grid.Point) []search.Edge[grid.Point] {
+			ns := grid.Neighbors4[int](g, p)
+			edges := make([]search.Edge[grid.Point], 0, len(ns))
+			for _, i := range ns {
+				edges = append(edges, search.Edge[grid.Point]{
+					To:   i,
+					Cost: g.Get(i),
+				})
+			}
+			return edges
+		},
+	}
+	return graph.AStar(start, func(p grid.Point) bool {
+		return p == end
+	}, func(p grid.Point) int {
+		return p.Manhattan(end)
+	}).Cost
+}
+
+func parse(r io.Reader) (*grid.Dense[int], error) {
+	rows, err := input.Grid(r)
+	if err != nil {
+		return nil, err
+	}
+	return grid.Parse(rows, rowFormat, func(c byte) (int, error) {
+		if c < '1' || c > '9' {
+			return 0, ErrInvalidRisk
+		}
+		return int(c - '0'), nil
+	})
+}
+
+func solveTiled(r io.Reader, tiles int) (solver.Answer, error) {
+	g, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	if g.Height() == 0 {
+		return solver.Answer{}, nil
+	}
+	g = g.Tile(tiles, tiles, func(v int, tx, ty int) int {
+		return (v+tx+ty-1)%9 + 1
+	})
+	return solver.Int(pathfind(g, grid.Point{X: 0, Y: 0}, grid.Point{X: g.Width() - 1, Y: g.Height() - 1})), nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	return solveTiled(r, 1)
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	return solveTiled(r, 5)
+}
@@ -0,0 +1,37 @@
+package day16
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/bits"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+func decode(r io.Reader) (*bits.Packet, error) {
+	bitstream, err := input.Hex(r)
+	if err != nil {
+		return nil, err
+	}
+	return bits.Decode(bitstream)
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	p, err := decode(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(p.VersionSum()), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	p, err := decode(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	val, err := p.Eval()
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(val), nil
+}
@@ -0,0 +1,89 @@
+package day16
+
+import (
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/solver"
+	"github.com/xorkevin/advent2021/internal/solvertest"
+)
+
+func TestSolve(t *testing.T) {
+	s := solver.Parts{Part1: Part1, Part2: Part2}
+	solvertest.Run(t, s, []solvertest.Case{
+		{
+			Name:  "8A004A801A8002F478",
+			Text:  "8A004A801A8002F478",
+			Part1: "16",
+			Part2: "15",
+		},
+		{
+			Name:  "620080001611562C8802118E34",
+			Text:  "620080001611562C8802118E34",
+			Part1: "12",
+			Part2: "46",
+		},
+		{
+			Name:  "C0015000016115A2E0802F182340",
+			Text:  "C0015000016115A2E0802F182340",
+			Part1: "23",
+			Part2: "46",
+		},
+		{
+			Name:  "A0016C880162017C3686B18A3D4780",
+			Text:  "A0016C880162017C3686B18A3D4780",
+			Part1: "31",
+			Part2: "54",
+		},
+		{
+			Name:  "C200B40A82",
+			Text:  "C200B40A82",
+			Part1: "14",
+			Part2: "3",
+		},
+		{
+			Name:  "04005AC33890",
+			Text:  "04005AC33890",
+			Part1: "8",
+			Part2: "54",
+		},
+		{
+			Name:  "880086C3E88112",
+			Text:  "880086C3E88112",
+			Part1: "15",
+			Part2: "7",
+		},
+		{
+			Name:  "CE00C43D881120",
+			Text:  "CE00C43D881120",
+			Part1: "11",
+			Part2: "9",
+		},
+		{
+			Name:  "D8005AC2A8F0",
+			Text:  "D8005AC2A8F0",
+			Part1: "13",
+			Part2: "1",
+		},
+		{
+			Name:  "F600BC2D8F",
+			Text:  "F600BC2D8F",
+			Part1: "19",
+			Part2: "0",
+		},
+		{
+			Name:  "9C005AC2F8F0",
+			Text:  "9C005AC2F8F0",
+			Part1: "16",
+			Part2: "0",
+		},
+	})
+	solvertest.Manifest(t, s)
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.Bench(b, Part1, "input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.Bench(b, Part2, "input.txt")
+}
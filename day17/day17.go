@@ -0,0 +1,187 @@
+package day17
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidLine = errors.New("Invalid line")
+	ErrEmptyRange  = errors.New("Empty range")
+	ErrUnbounded   = errors.New("Target spans the launch height, so infinitely many velocities may hit it")
+	ErrNoHit       = errors.New("No velocity hits the target")
+)
+
+const (
+	linePrefix = "target area: x="
+	lineFormat = "target area: x=<int>..<int>, y=<int>..<int>"
+)
+
+type (
+	Vec2 struct {
+		x int
+		y int
+	}
+
+	// Target is the area the probe must land in, where the bounds are
+	// inclusive
+	Target struct {
+		X1, X2, Y1, Y2 int
+	}
+)
+
+// parseRange parses a range a..b which begins at the 1-indexed column
+func parseRange(s string, col int) (int, int, error) {
+	a, b, ok := strings.Cut(s, "..")
+	if !ok {
+		return 0, 0, input.NewParseError(1, col, lineFormat, ErrInvalidLine)
+	}
+	lo, err := input.Atoi(a, 1, col, lineFormat)
+	if err != nil {
+		return 0, 0, err
+	}
+	hi, err := input.Atoi(b, 1, col+len(a)+len(".."), lineFormat)
+	if err != nil {
+		return 0, 0, err
+	}
+	if lo > hi {
+		return 0, 0, input.NewParseError(1, col, lineFormat, fmt.Errorf("%w: %d..%d", ErrEmptyRange, lo, hi))
+	}
+	return lo, hi, nil
+}
+
+// ParseTarget parses a target area line
+func ParseTarget(r io.Reader) (Target, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return Target{}, err
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) != 1 {
+		return Target{}, input.NewParseError(1, 0, lineFormat, fmt.Errorf("%w: want 1 line, got %d", ErrInvalidLine, len(lines)))
+	}
+	line := lines[0]
+	if !strings.HasPrefix(line, linePrefix) {
+		return Target{}, input.NewParseError(1, 0, lineFormat, ErrInvalidLine)
+	}
+	xs, ys, ok := strings.Cut(line[len(linePrefix):], ", y=")
+	if !ok {
+		return Target{}, input.NewParseError(1, 0, lineFormat, ErrInvalidLine)
+	}
+	var t Target
+	xcol := len(linePrefix) + 1
+	if t.X1, t.X2, err = parseRange(xs, xcol); err != nil {
+		return Target{}, err
+	}
+	if t.Y1, t.Y2, err = parseRange(ys, xcol+len(xs)+len(", y=")); err != nil {
+		return Target{}, err
+	}
+	return t, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// trajectories returns the highest point reached by any velocity which hits
+// the target, and the number of velocities which hit it. The first step moves
+// by the whole velocity, so x velocities lie between 0 and the far edge of the
+// target. A probe launched up at vy returns to the launch height moving down at
+// vy+1, so for a target below vy lies between its bottom and -bottom-1, and
+// for a target above between 0 and its top.
+func (t Target) trajectories() (int, int, error) {
+	if t.Y1 <= 0 && t.Y2 >= 0 {
+		return 0, 0, ErrUnbounded
+	}
+	maxy, count := 0, 0
+	for vx := minInt(t.X1, 0); vx <= maxInt(t.X2, 0); vx++ {
+		for vy := minInt(t.Y1, 0); vy <= maxInt(t.Y2, -t.Y1-1); vy++ {
+			k, ok := simulate(Vec2{0, 0}, Vec2{vx, vy}, t.X1, t.X2, t.Y1, t.Y2)
+			if !ok {
+				continue
+			}
+			if count == 0 || k > maxy {
+				maxy = k
+			}
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, 0, ErrNoHit
+	}
+	return maxy, count, nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	t, err := ParseTarget(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	maxy, _, err := t.trajectories()
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(maxy), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	t, err := ParseTarget(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	_, count, err := t.trajectories()
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(count), nil
+}
+
+func simulate(p Vec2, v Vec2, x1, x2, y1, y2 int) (int, bool) {
+	maxy := 0
+	for {
+		p.x += v.x
+		p.y += v.y
+
+		if v.x > 0 {
+			v.x--
+		} else if v.x < 0 {
+			v.x++
+		}
+		v.y--
+
+		if p.y > maxy {
+			maxy = p.y
+		}
+
+		if inTarget(p, x1, x2, y1, y2) {
+			return maxy, true
+		}
+		if p.y < y1 && v.y < 0 {
+			return 0, false
+		}
+		if v.x == 0 && (p.x < x1 || p.x > x2) {
+			return 0, false
+		}
+	}
+}
+
+func inTarget(p Vec2, x1, x2, y1, y2 int) bool {
+	return p.x >= x1 && p.x <= x2 && p.y >= y1 && p.y <= y2
+}
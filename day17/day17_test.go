@@ -0,0 +1,88 @@
+package day17
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+	"github.com/xorkevin/advent2021/internal/solvertest"
+)
+
+func TestSolve(t *testing.T) {
+	s := solver.Parts{Part1: Part1, Part2: Part2}
+	solvertest.Run(t, s, []solvertest.Case{
+		{
+			Name:  "example",
+			Text:  "target area: x=20..30, y=-10..-5\n",
+			Part1: "45",
+			Part2: "112",
+		},
+	})
+	solvertest.Manifest(t, s)
+}
+
+func TestTrajectories(t *testing.T) {
+	for _, tc := range []Target{
+		{20, 30, -10, -5},
+		{-30, -20, -10, -5},
+		{-3, 4, -8, -2},
+		{5, 12, 3, 9},
+	} {
+		maxy, count, err := tc.trajectories()
+		if err != nil {
+			t.Fatal(err)
+		}
+		// every velocity within a much larger box
+		wantMax, wantCount := 0, 0
+		for vx := -100; vx <= 100; vx++ {
+			for vy := -100; vy <= 100; vy++ {
+				k, ok := simulate(Vec2{0, 0}, Vec2{vx, vy}, tc.X1, tc.X2, tc.Y1, tc.Y2)
+				if !ok {
+					continue
+				}
+				if wantCount == 0 || k > wantMax {
+					wantMax = k
+				}
+				wantCount++
+			}
+		}
+		if maxy != wantMax || count != wantCount {
+			t.Errorf("%v: want %d and %d, got %d and %d", tc, wantMax, wantCount, maxy, count)
+		}
+	}
+	if _, _, err := (Target{1, 5, -2, 3}).trajectories(); !errors.Is(err, ErrUnbounded) {
+		t.Errorf("want error %v, got %v", ErrUnbounded, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		err  error
+		col  int
+	}{
+		{"", ErrInvalidLine, 0},
+		{"target: x=1..2, y=-3..-1", ErrInvalidLine, 0},
+		{"target area: x=1..2 y=-3..-1", ErrInvalidLine, 0},
+		{"target area: x=1-2, y=-3..-1", ErrInvalidLine, 16},
+		{"target area: x=1..b, y=-3..-1", input.ErrInvalidInt, 19},
+		{"target area: x=1..2, y=-1..-3", ErrEmptyRange, 24},
+		{"target area: x=1..2, y=-3..-1\ntarget area: x=1..2, y=-3..-1", ErrInvalidLine, 0},
+	} {
+		_, err := ParseTarget(strings.NewReader(tc.text))
+		var perr *input.ParseError
+		if !errors.Is(err, tc.err) || !errors.As(err, &perr) || perr.Col != tc.col {
+			t.Errorf("%q: want error %v at column %d, got %v", tc.text, tc.err, tc.col, err)
+		}
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.Bench(b, Part1, "input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.Bench(b, Part2, "input.txt")
+}
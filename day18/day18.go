@@ -1,21 +1,16 @@
-package main
+package day18
 
 import (
-	"bufio"
 	"errors"
-	"fmt"
-	"log"
-	"os"
+	"io"
 	"strconv"
 	"strings"
 
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
 	"xorkevin.dev/gnom"
 )
 
-const (
-	puzzleInput = "input.txt"
-)
-
 const (
 	tokenKindDefault = iota
 	tokenKindEOF
@@ -29,6 +24,10 @@ var (
 	ErrParse = errors.New("Parse error")
 )
 
+const (
+	lineFormat = "[a,b] where a and b are ints or pairs"
+)
+
 type (
 	Pair struct {
 		val int
@@ -61,7 +60,7 @@ func (p Pair) String() string {
 
 func parsePairs(tokens []gnom.Token) (*Pair, []gnom.Token, error) {
 	if len(tokens) == 0 {
-		return nil, nil, ErrParse
+		return nil, tokens, ErrParse
 	}
 	top := tokens[0]
 	switch top.Kind() {
@@ -69,7 +68,7 @@ func parsePairs(tokens []gnom.Token) (*Pair, []gnom.Token, error) {
 		{
 			num, err := strconv.Atoi(top.Val())
 			if err != nil {
-				return nil, nil, ErrParse
+				return nil, tokens, ErrParse
 			}
 			return &Pair{
 				val: num,
@@ -81,24 +80,24 @@ func parsePairs(tokens []gnom.Token) (*Pair, []gnom.Token, error) {
 			var err error
 			lhs, tokens, err = parsePairs(tokens[1:])
 			if err != nil {
-				return nil, nil, err
+				return nil, tokens, err
 			}
 			if len(tokens) == 0 {
-				return nil, nil, ErrParse
+				return nil, tokens, ErrParse
 			}
 			if tokens[0].Kind() != tokenKindComma {
-				return nil, nil, ErrParse
+				return nil, tokens, ErrParse
 			}
 			var rhs *Pair
 			rhs, tokens, err = parsePairs(tokens[1:])
 			if err != nil {
-				return nil, nil, ErrParse
+				return nil, tokens, err
 			}
 			if len(tokens) == 0 {
-				return nil, nil, ErrParse
+				return nil, tokens, ErrParse
 			}
 			if tokens[0].Kind() != tokenKindRparen {
-				return nil, nil, ErrParse
+				return nil, tokens, ErrParse
 			}
 			return &Pair{
 				lhs: lhs,
@@ -106,7 +105,7 @@ func parsePairs(tokens []gnom.Token) (*Pair, []gnom.Token, error) {
 			}, tokens[1:], nil
 		}
 	default:
-		return nil, nil, ErrParse
+		return nil, tokens, ErrParse
 	}
 }
 
@@ -132,7 +131,7 @@ func (p *Pair) explode(depth int) (int, int, bool) {
 	}
 	if depth > 3 {
 		if !p.lhs.IsLiteral() || !p.rhs.IsLiteral() {
-			log.Fatalln("Invalid reduction state")
+			panic("Invalid reduction state")
 		}
 		l := p.lhs.val
 		r := p.rhs.val
@@ -209,16 +208,20 @@ func (p Pair) Clone() *Pair {
 	}
 }
 
-func main() {
-	file, err := os.Open(puzzleInput)
+// tokenCol returns the column of the first of the remaining tokens of line
+func tokenCol(line string, rest []gnom.Token) int {
+	remaining := 0
+	for _, i := range rest {
+		remaining += len([]rune(i.Val()))
+	}
+	return len([]rune(line)) - remaining + 1
+}
+
+func parse(r io.Reader) ([]*Pair, error) {
+	lines, err := input.Lines(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
 
 	dfa := gnom.NewDfa(tokenKindDefault)
 	dfaNum := gnom.NewDfa(tokenKindNum)
@@ -229,16 +232,29 @@ func main() {
 	dfa.AddPath([]rune(","), tokenKindComma, tokenKindDefault)
 	lexer := gnom.NewDfaLexer(dfa, tokenKindDefault, tokenKindEOF, map[int]struct{}{})
 
-	var nums []*Pair
-	var root *Pair
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		tokens, err := lexer.Tokenize([]rune(scanner.Text()))
+	nums := make([]*Pair, 0, len(lines))
+	for n, line := range lines {
+		tokens, err := lexer.Tokenize([]rune(line))
+		if err != nil {
+			return nil, input.NewParseError(n+1, 0, lineFormat, err)
+		}
+		pair, rest, err := parsePairs(tokens)
 		if err != nil {
-			log.Fatal(err)
+			return nil, input.NewParseError(n+1, tokenCol(line, rest), lineFormat, err)
 		}
-		pair, _, err := parsePairs(tokens)
-		nums = append(nums, pair.Clone())
+		nums = append(nums, pair)
+	}
+	return nums, nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	nums, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	var root *Pair
+	for _, pair := range nums {
 		if root == nil {
 			root = pair
 		} else {
@@ -250,11 +266,21 @@ func main() {
 		root.Reduce()
 	}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+	if root == nil {
+		return solver.Answer{}, nil
+	}
+	return solver.Int(root.Magnitude()), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	nums, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
 
-	fmt.Println("Part 1:", root.Magnitude())
+	if len(nums) == 0 {
+		return solver.Answer{}, nil
+	}
 
 	maxmag := 0
 	for i := 0; i < len(nums); i++ {
@@ -277,7 +303,7 @@ func main() {
 			}
 		}
 	}
-	fmt.Println("Part 2:", maxmag)
+	return solver.Int(maxmag), nil
 }
 
 func max(a, b int) int {
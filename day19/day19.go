@@ -1,16 +1,21 @@
-package main
+package day19
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
-	"strconv"
+	"errors"
+	"io"
 	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidLine = errors.New("Invalid line")
+	ErrTransform   = errors.New("Failed to find transform")
 )
 
 const (
-	puzzleInput = "input.txt"
+	lineFormat = "x,y,z"
 )
 
 type (
@@ -283,62 +288,52 @@ func alignScanner(s *ScannerLog, t1 Vec3, t2 Mat3, t3 Vec3) {
 	}
 }
 
-func main() {
-	file, err := os.Open(puzzleInput)
+func parse(r io.Reader) ([]*ScannerLog, error) {
+	lines, err := input.Lines(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
 
 	var scannerlogs []*ScannerLog
 	var id string
 	var scans []Vec3
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			scannerlogs = append(scannerlogs, NewScannerLog(id, scans))
-			scans = nil
+	inBlock := false
+	for n, line := range lines {
+		if line == "" {
+			if inBlock {
+				scannerlogs = append(scannerlogs, NewScannerLog(id, scans))
+				id = ""
+				scans = nil
+				inBlock = false
+			}
 			continue
 		}
+		inBlock = true
 		if strings.HasPrefix(line, "---") {
 			id = line
 			continue
 		}
-		arr := strings.SplitN(line, ",", 3)
+		arr, cols := input.Split(line, ",")
 		if len(arr) != 3 {
-			log.Fatalln("Invalid line")
-		}
-		x, err := strconv.Atoi(arr[0])
-		if err != nil {
-			log.Fatal(err)
+			return nil, input.NewParseError(n+1, 0, lineFormat, ErrInvalidLine)
 		}
-		y, err := strconv.Atoi(arr[1])
-		if err != nil {
-			log.Fatal(err)
-		}
-		z, err := strconv.Atoi(arr[2])
-		if err != nil {
-			log.Fatal(err)
+		var k [3]int
+		for j, i := range arr {
+			v, err := input.Atoi(i, n+1, cols[j], lineFormat)
+			if err != nil {
+				return nil, err
+			}
+			k[j] = v
 		}
-		scans = append(scans, Vec3{x, y, z})
-	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		scans = append(scans, Vec3{k[0], k[1], k[2]})
 	}
-
-	if len(scans) > 0 {
+	if inBlock {
 		scannerlogs = append(scannerlogs, NewScannerLog(id, scans))
-		id = ""
-		scans = nil
 	}
+	return scannerlogs, nil
+}
 
+func align(scannerlogs []*ScannerLog) ([]*ScannerLog, error) {
 	alignedScanners := make([]*ScannerLog, 0, len(scannerlogs))
 	alignedScanners = append(alignedScanners, scannerlogs[0])
 	scannerlogs = scannerlogs[1:]
@@ -357,7 +352,7 @@ func main() {
 				aa, ab := get3Vec(assignment)
 				t1, t2, t3, ok := findTransform(aa, ab)
 				if !ok {
-					log.Fatalln("Failed to find transform")
+					return nil, ErrTransform
 				}
 				alignScanner(scannerlogs[i], t1, t2, t3)
 				alignedScanners = append(alignedScanners, scannerlogs[i])
@@ -368,14 +363,46 @@ func main() {
 		}
 	}
 
+	return alignedScanners, nil
+}
+
+func parseAligned(r io.Reader) ([]*ScannerLog, error) {
+	scannerlogs, err := parse(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(scannerlogs) == 0 {
+		return nil, nil
+	}
+	return align(scannerlogs)
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	alignedScanners, err := parseAligned(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	if len(alignedScanners) == 0 {
+		return solver.Answer{}, nil
+	}
+
 	grid := map[Vec3]struct{}{}
 	for _, i := range alignedScanners {
 		for _, j := range i.Scans {
 			grid[j] = struct{}{}
 		}
 	}
+	return solver.Int(len(grid)), nil
+}
 
-	fmt.Println("Part 1:", len(grid))
+func Part2(r io.Reader) (solver.Answer, error) {
+	alignedScanners, err := parseAligned(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	if len(alignedScanners) == 0 {
+		return solver.Answer{}, nil
+	}
 
 	maxDist := 0
 	for i := 0; i < len(alignedScanners); i++ {
@@ -387,5 +414,5 @@ func main() {
 		}
 	}
 
-	fmt.Println("Part 2:", maxDist)
+	return solver.Int(maxDist), nil
 }
@@ -0,0 +1,154 @@
+package day20
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/grid"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidPixel = errors.New("Invalid pixel")
+	ErrInvalidAlg   = errors.New("Invalid enhancement algorithm")
+	ErrInfinite     = errors.New("Infinitely many pixels lit")
+)
+
+const (
+	algFormat = "512 pixels of . or #"
+	rowFormat = "pixels . or #"
+)
+
+type (
+	Image = grid.Sparse[bool]
+)
+
+func getPixelIndex(g *Image, p grid.Point) int {
+	k := 0
+	for a := -1; a <= 1; a++ {
+		y := p.Y + a
+		for b := -1; b <= 1; b++ {
+			x := p.X + b
+			k = k << 1
+			if g.Has(grid.Point{X: x, Y: y}) {
+				k += 1
+			}
+		}
+	}
+	return k
+}
+
+const (
+	modeBase = iota
+	modeInv
+	modeUnInv
+)
+
+func enhanceAlg(g *Image, alg []byte, mode int) *Image {
+	next := grid.NewSparse[bool]()
+	g.Range(func(p grid.Point, _ bool) {
+		for a := -1; a <= 1; a++ {
+			y := p.Y + a
+			for b := -1; b <= 1; b++ {
+				x := p.X + b
+				k := grid.Point{X: x, Y: y}
+				c := getPixelIndex(g, k)
+				switch mode {
+				case modeInv:
+					// in this mode, we are reading '#', and are storing '.'
+					if alg[c] == '.' {
+						next.Set(k, true)
+					}
+				case modeUnInv:
+					// in this mode, we are reading '.', and are storing '#'
+					if alg[c^0b111111111] == '#' {
+						next.Set(k, true)
+					}
+				default:
+					// in this mode, we are reading '#', and are storing '#'
+					if alg[c] == '#' {
+						next.Set(k, true)
+					}
+				}
+			}
+		}
+	})
+	return next
+}
+
+func parse(r io.Reader) ([]byte, *Image, error) {
+	lines, err := input.Grid(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(lines) == 0 || len(lines[0]) != 512 {
+		return nil, nil, input.NewParseError(1, 0, algFormat, ErrInvalidAlg)
+	}
+	alg := lines[0]
+	for n, i := range alg {
+		if i != '.' && i != '#' {
+			return nil, nil, input.NewParseError(1, n+1, algFormat, ErrInvalidAlg)
+		}
+	}
+
+	// the image begins after the blank line following the algorithm
+	start := 1
+	if len(lines) > 1 && len(lines[1]) == 0 {
+		start = 2
+	}
+	rows := lines[start:]
+	for len(rows) > 0 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+	g, err := grid.Parse(rows, rowFormat, func(c byte) (bool, error) {
+		switch c {
+		case '#':
+			return true, nil
+		case '.':
+			return false, nil
+		default:
+			return false, ErrInvalidPixel
+		}
+	})
+	if err != nil {
+		var perr *input.ParseError
+		if errors.As(err, &perr) {
+			perr.Line += start
+		}
+		return nil, nil, err
+	}
+	return alg, g.Sparse(func(lit bool) bool { return lit }), nil
+}
+
+func enhance(r io.Reader, steps int) (solver.Answer, error) {
+	alg, points, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	// the infinite background of . stays dark if the algorithm maps 0 to .,
+	// and otherwise must flash back to dark every other step
+	if alg[0] == '.' {
+		for i := 0; i < steps; i++ {
+			points = enhanceAlg(points, alg, modeBase)
+		}
+		return solver.Int(points.Len()), nil
+	}
+	if alg[len(alg)-1] == '#' || steps%2 != 0 {
+		return solver.Answer{}, ErrInfinite
+	}
+	for i := 0; i < steps/2; i++ {
+		points = enhanceAlg(points, alg, modeInv)
+		points = enhanceAlg(points, alg, modeUnInv)
+	}
+	return solver.Int(points.Len()), nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	return enhance(r, 2)
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	return enhance(r, 50)
+}
@@ -0,0 +1,29 @@
+package day20
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/solver"
+	"github.com/xorkevin/advent2021/internal/solvertest"
+)
+
+func TestSolve(t *testing.T) {
+	solvertest.Manifest(t, solver.Parts{Part1: Part1, Part2: Part2})
+}
+
+func TestInfinite(t *testing.T) {
+	alg := strings.Repeat("#", 512)
+	if _, err := Part1(strings.NewReader(alg + "\n\n#.\n")); !errors.Is(err, ErrInfinite) {
+		t.Errorf("want error %v, got %v", ErrInfinite, err)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.Bench(b, Part1, "input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.Bench(b, Part2, "input.txt")
+}
@@ -0,0 +1,286 @@
+package day21
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/memo"
+)
+
+type (
+	// Odds are the chances from a state of a game played with a fair die,
+	// where every roll is equally likely. These differ from the shares of
+	// universes won, since longer games split into more universes.
+	Odds struct {
+		// WinProb is the probability that each player wins
+		WinProb []float64 `json:"win_prob"`
+		// ExpectedTurns is the expected number of turns until the game ends
+		ExpectedTurns float64 `json:"expected_turns"`
+	}
+
+	// StateOdds are the odds from a state reachable in a game
+	StateOdds struct {
+		// Turn is the 1-indexed player to move
+		Turn int `json:"turn"`
+		// Pos is the 1-indexed position of each player
+		Pos []int `json:"pos"`
+		// Score is the score of each player
+		Score []int `json:"score"`
+		Odds
+	}
+
+	// StartOption is the odds of a player starting from a position
+	StartOption struct {
+		Pos     int     `json:"pos"`
+		WinProb float64 `json:"win_prob"`
+		// Universes is the number of universes the player wins in
+		Universes int `json:"universes"`
+	}
+
+	// StartChoice is every starting position of a player given the starting
+	// positions of the other players, and the one with the highest chance of
+	// winning
+	StartChoice struct {
+		// Player is 1-indexed
+		Player  int           `json:"player"`
+		Best    int           `json:"best"`
+		Options []StartOption `json:"options"`
+	}
+
+	// Analysis explores a quantum game from its starting positions
+	Analysis struct {
+		Game  DiracGame `json:"game"`
+		Start []int     `json:"start"`
+		// Universes is the number of universes each player wins in
+		Universes []int `json:"universes"`
+		Odds
+		BestStart []StartChoice `json:"best_start"`
+		// States holds the odds from every state reachable from the start, if
+		// they were requested
+		States []StateOdds `json:"states,omitempty"`
+	}
+
+	analyzer struct {
+		q    *quantum
+		odds *memo.Memo[State, Odds]
+	}
+)
+
+func (g DiracGame) newAnalyzer() *analyzer {
+	return &analyzer{
+		q:    g.newQuantum(),
+		odds: memo.New[State, Odds](0),
+	}
+}
+
+// total returns the number of equally likely rolls of a turn
+func (a *analyzer) total() int {
+	k := 0
+	for _, i := range a.q.steps {
+		k += i.Count
+	}
+	return k
+}
+
+// stateOdds returns the odds from s, whose slices must not be modified
+func (a *analyzer) stateOdds(s State) Odds {
+	return a.odds.Do(s, func() Odds {
+		total := float64(a.total())
+		o := Odds{
+			WinProb:       make([]float64, a.q.g.Players),
+			ExpectedTurns: 1,
+		}
+		a.q.expand(s, func(r Roll, next State, won bool) {
+			p := float64(r.Count) / total
+			if won {
+				o.WinProb[s.Turn] += p
+				return
+			}
+			k := a.stateOdds(next)
+			for n, i := range k.WinProb {
+				o.WinProb[n] += p * i
+			}
+			o.ExpectedTurns += p * k.ExpectedTurns
+		})
+		return o
+	})
+}
+
+func copyOdds(o Odds) Odds {
+	return Odds{
+		WinProb:       append([]float64{}, o.WinProb...),
+		ExpectedTurns: o.ExpectedTurns,
+	}
+}
+
+// reachable returns every state which the game may reach from s before it
+// ends, including s
+func (a *analyzer) reachable(s State) []State {
+	seen := map[State]struct{}{s: {}}
+	states := []State{s}
+	for n := 0; n < len(states); n++ {
+		a.q.expand(states[n], func(r Roll, next State, won bool) {
+			if won {
+				return
+			}
+			if _, ok := seen[next]; ok {
+				return
+			}
+			seen[next] = struct{}{}
+			states = append(states, next)
+		})
+	}
+	return states
+}
+
+func (a *analyzer) toStateOdds(s State) StateOdds {
+	n := a.q.g.Players
+	pos := make([]int, n)
+	for i := range pos {
+		pos[i] = s.Pos[i] + 1
+	}
+	return StateOdds{
+		Turn:  s.Turn + 1,
+		Pos:   pos,
+		Score: append([]int{}, s.Score[:n]...),
+		Odds:  copyOdds(a.stateOdds(s)),
+	}
+}
+
+// bestStart tries every starting position of the player with the others fixed
+func (a *analyzer) bestStart(start []int, player int) StartChoice {
+	c := StartChoice{
+		Player:  player + 1,
+		Options: make([]StartOption, 0, a.q.g.BoardSize),
+	}
+	best := -1.0
+	k := append([]int{}, start...)
+	for pos := 1; pos <= a.q.g.BoardSize; pos++ {
+		k[player] = pos
+		s := startState(k)
+		o := StartOption{
+			Pos:       pos,
+			WinProb:   a.stateOdds(s).WinProb[player],
+			Universes: a.q.wins(s)[player],
+		}
+		c.Options = append(c.Options, o)
+		if o.WinProb > best {
+			best = o.WinProb
+			c.Best = pos
+		}
+	}
+	return c
+}
+
+// Analyze returns the universes won, the odds of the game, and the best
+// starting position of each player given the others. The odds from every
+// reachable state are included if states is set.
+func (g DiracGame) Analyze(start []int, states bool) (*Analysis, error) {
+	if err := g.validateStart(start); err != nil {
+		return nil, err
+	}
+	a := g.newAnalyzer()
+	s := startState(start)
+	res := &Analysis{
+		Game:      g,
+		Start:     append([]int{}, start...),
+		Universes: append([]int{}, a.q.wins(s)...),
+		Odds:      copyOdds(a.stateOdds(s)),
+	}
+	for i := 0; i < g.Players; i++ {
+		res.BestStart = append(res.BestStart, a.bestStart(start, i))
+	}
+	if states {
+		for _, i := range a.reachable(s) {
+			res.States = append(res.States, a.toStateOdds(i))
+		}
+		sort.Slice(res.States, func(i, j int) bool {
+			return lessState(res.States[i], res.States[j])
+		})
+	}
+	return res, nil
+}
+
+// lessState orders states by the total score, then the player to move, then
+// the positions and scores of each player
+func lessState(a, b StateOdds) bool {
+	sa, sb := sum(a.Score), sum(b.Score)
+	if sa != sb {
+		return sa < sb
+	}
+	if a.Turn != b.Turn {
+		return a.Turn < b.Turn
+	}
+	for n := range a.Pos {
+		if a.Pos[n] != b.Pos[n] {
+			return a.Pos[n] < b.Pos[n]
+		}
+		if a.Score[n] != b.Score[n] {
+			return a.Score[n] < b.Score[n]
+		}
+	}
+	return false
+}
+
+func sum(a []int) int {
+	k := 0
+	for _, i := range a {
+		k += i
+	}
+	return k
+}
+
+func formatProb(v float64) string {
+	return strconv.FormatFloat(v, 'f', 6, 64)
+}
+
+func joinInts(a []int) string {
+	s := make([]string, 0, len(a))
+	for _, i := range a {
+		s = append(s, strconv.Itoa(i))
+	}
+	return strings.Join(s, ",")
+}
+
+// WriteTable writes the analysis as text tables
+func (a *Analysis) WriteTable(w io.Writer) error {
+	g := a.Game
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%d players, board %d, win score %d, %d rolls of a %d sided die per turn\n", g.Players, g.BoardSize, g.WinScore, g.Rolls, g.DieSides)
+	fmt.Fprintf(b, "expected turns %s\n\n", strconv.FormatFloat(a.ExpectedTurns, 'f', 3, 64))
+	const format = "%-8s %-6s %20s %10s %6s %10s\n"
+	fmt.Fprintf(b, format, "player", "start", "universes", "win prob", "best", "best prob")
+	for n := range a.Start {
+		c := a.BestStart[n]
+		fmt.Fprintf(b, format,
+			strconv.Itoa(n+1),
+			strconv.Itoa(a.Start[n]),
+			strconv.Itoa(a.Universes[n]),
+			formatProb(a.WinProb[n]),
+			strconv.Itoa(c.Best),
+			formatProb(c.Options[c.Best-1].WinProb),
+		)
+	}
+	if len(a.States) > 0 {
+		const stateFormat = "%-6s %-16s %-16s %-32s %s\n"
+		fmt.Fprintf(b, "\n"+stateFormat, "turn", "pos", "score", "win prob", "expected turns")
+		for _, i := range a.States {
+			probs := make([]string, 0, len(i.WinProb))
+			for _, j := range i.WinProb {
+				probs = append(probs, formatProb(j))
+			}
+			fmt.Fprintf(b, stateFormat,
+				strconv.Itoa(i.Turn),
+				joinInts(i.Pos),
+				joinInts(i.Score),
+				strings.Join(probs, ","),
+				strconv.FormatFloat(i.ExpectedTurns, 'f', 3, 64),
+			)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,337 @@
+package day21
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/memo"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidLine     = errors.New("Invalid line")
+	ErrInvalidPlayer   = errors.New("Players out of order")
+	ErrInvalidPosition = errors.New("Position off the board")
+	ErrInvalidGame     = errors.New("Invalid game")
+	ErrPlayers         = errors.New("Wrong number of players")
+)
+
+const (
+	lineFormat = "Player <n> starting position: <position>"
+
+	// MaxPlayers is the most players a game may have
+	MaxPlayers = 8
+)
+
+type (
+	// DiracGame is the rules of a game of Dirac Dice
+	DiracGame struct {
+		// BoardSize is the number of spaces on the circular board, numbered
+		// from 1
+		BoardSize int `json:"board_size"`
+		// WinScore is the score at which a player wins
+		WinScore int `json:"win_score"`
+		// DieSides is the number of sides of the die, numbered from 1
+		DieSides int `json:"die_sides"`
+		// Rolls is the number of times the die is rolled each turn
+		Rolls int `json:"rolls"`
+		// Players is the number of players
+		Players int `json:"players"`
+	}
+)
+
+var (
+	// PracticeGame is the game of part 1, played with a deterministic die
+	PracticeGame = DiracGame{
+		BoardSize: 10,
+		WinScore:  1000,
+		DieSides:  100,
+		Rolls:     3,
+		Players:   2,
+	}
+	// QuantumGame is the game of part 2, played with a quantum die
+	QuantumGame = DiracGame{
+		BoardSize: 10,
+		WinScore:  21,
+		DieSides:  3,
+		Rolls:     3,
+		Players:   2,
+	}
+)
+
+// Validate returns an error if any of the rules are not positive, or there
+// are more than MaxPlayers players
+func (g DiracGame) Validate() error {
+	if g.BoardSize < 1 || g.WinScore < 1 || g.DieSides < 1 || g.Rolls < 1 {
+		return fmt.Errorf("%w: board size, win score, die sides, and rolls must be positive", ErrInvalidGame)
+	}
+	if g.Players < 1 || g.Players > MaxPlayers {
+		return fmt.Errorf("%w: %d players, must be 1 to %d", ErrInvalidGame, g.Players, MaxPlayers)
+	}
+	return nil
+}
+
+// validateStart checks the 1-indexed starting positions of the players
+func (g DiracGame) validateStart(start []int) error {
+	if err := g.Validate(); err != nil {
+		return err
+	}
+	if len(start) != g.Players {
+		return fmt.Errorf("%w: want %d, got %d", ErrPlayers, g.Players, len(start))
+	}
+	for n, i := range start {
+		if i < 1 || i > g.BoardSize {
+			return fmt.Errorf("%w: player %d at %d on a board of %d", ErrInvalidPosition, n+1, i, g.BoardSize)
+		}
+	}
+	return nil
+}
+
+type (
+	DetDie struct {
+		sides int
+		k     int
+		rolls int
+	}
+)
+
+func (d *DetDie) Roll() int {
+	d.rolls++
+	v := d.k + 1
+	d.k = v % d.sides
+	return v
+}
+
+func turnRolls(d *DetDie, rolls int) int {
+	k := 0
+	for i := 0; i < rolls; i++ {
+		k += d.Roll()
+	}
+	return k
+}
+
+// Deterministic plays the game with a die which rolls 1, 2, 3, and so on, and
+// returns the lowest score of the losing players multiplied by the number of
+// rolls
+func (g DiracGame) Deterministic(start []int) (int, error) {
+	if err := g.validateStart(start); err != nil {
+		return 0, err
+	}
+	pos := make([]int, g.Players)
+	for n, i := range start {
+		pos[n] = i - 1
+	}
+	scores := make([]int, g.Players)
+	d := &DetDie{sides: g.DieSides}
+	for p := 0; ; p = (p + 1) % g.Players {
+		k := turnRolls(d, g.Rolls)
+		pos[p] = (pos[p] + k) % g.BoardSize
+		scores[p] += pos[p] + 1
+		if scores[p] >= g.WinScore {
+			break
+		}
+	}
+	l := -1
+	for _, i := range scores {
+		if i < g.WinScore && (l < 0 || i < l) {
+			l = i
+		}
+	}
+	if l < 0 {
+		// a single player game has no losers
+		l = 0
+	}
+	return l * d.rolls, nil
+}
+
+type (
+	// State is a point in a quantum game before a player's turn, with
+	// 0-indexed positions
+	State struct {
+		Pos   [MaxPlayers]int
+		Score [MaxPlayers]int
+		Turn  int
+	}
+
+	// Roll is a total of a turn's rolls and the number of universes in which
+	// it is rolled
+	Roll struct {
+		Total int
+		Count int
+	}
+
+	quantum struct {
+		g     DiracGame
+		steps []Roll
+		memo  *memo.Memo[State, []int]
+	}
+)
+
+// rollDistribution returns each total of a turn's rolls with the number of
+// universes in which it is rolled
+func (g DiracGame) rollDistribution() []Roll {
+	// counts[k] is the number of ways to roll a total of k
+	counts := []int{1}
+	for i := 0; i < g.Rolls; i++ {
+		next := make([]int, len(counts)+g.DieSides)
+		for k, c := range counts {
+			if c == 0 {
+				continue
+			}
+			for face := 1; face <= g.DieSides; face++ {
+				next[k+face] += c
+			}
+		}
+		counts = next
+	}
+	var steps []Roll
+	for k, c := range counts {
+		if c != 0 {
+			steps = append(steps, Roll{k, c})
+		}
+	}
+	return steps
+}
+
+func (g DiracGame) newQuantum() *quantum {
+	return &quantum{
+		g:     g,
+		steps: g.rollDistribution(),
+		memo:  memo.New[State, []int](0),
+	}
+}
+
+// startState returns the state before the first turn
+func startState(start []int) State {
+	var s State
+	for n, i := range start {
+		s.Pos[n] = i - 1
+	}
+	return s
+}
+
+// Universes plays the game with a die which splits the universe into one for
+// each of its sides on every roll, and returns the number of universes in
+// which each player wins
+func (g DiracGame) Universes(start []int) ([]int, error) {
+	if err := g.validateStart(start); err != nil {
+		return nil, err
+	}
+	wins := g.newQuantum().wins(startState(start))
+	k := make([]int, len(wins))
+	copy(k, wins)
+	return k, nil
+}
+
+// wins returns the number of universes in which each player wins from s,
+// which must not be modified
+func (q *quantum) wins(s State) []int {
+	return q.memo.Do(s, func() []int {
+		return q.countWins(s)
+	})
+}
+
+// expand calls fn with each roll of the turn from s, the state after it, and
+// whether it wins the game
+func (q *quantum) expand(s State, fn func(r Roll, next State, won bool)) {
+	p := s.Turn
+	for _, i := range q.steps {
+		next := s
+		next.Pos[p] = (s.Pos[p] + i.Total) % q.g.BoardSize
+		next.Score[p] += next.Pos[p] + 1
+		if next.Score[p] >= q.g.WinScore {
+			fn(i, next, true)
+			continue
+		}
+		next.Turn = (p + 1) % q.g.Players
+		fn(i, next, false)
+	}
+}
+
+func (q *quantum) countWins(s State) []int {
+	wins := make([]int, q.g.Players)
+	q.expand(s, func(r Roll, next State, won bool) {
+		if won {
+			wins[s.Turn] += r.Count
+			return
+		}
+		for n, k := range q.wins(next) {
+			wins[n] += k * r.Count
+		}
+	})
+	return wins
+}
+
+// ParseStart returns the 1-indexed starting position of each player, ignoring
+// trailing blank lines
+func ParseStart(r io.Reader) ([]int, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	start := make([]int, 0, len(lines))
+	for n, line := range lines {
+		rest := strings.TrimPrefix(line, "Player ")
+		if len(rest) == len(line) {
+			return nil, input.NewParseError(n+1, 0, lineFormat, ErrInvalidLine)
+		}
+		player, pos, ok := strings.Cut(rest, " starting position: ")
+		if !ok {
+			return nil, input.NewParseError(n+1, 0, lineFormat, ErrInvalidLine)
+		}
+		p, err := input.Atoi(player, n+1, len("Player ")+1, lineFormat)
+		if err != nil {
+			return nil, err
+		}
+		if p != n+1 {
+			return nil, input.NewParseError(n+1, len("Player ")+1, lineFormat, fmt.Errorf("%w: want player %d, got %d", ErrInvalidPlayer, n+1, p))
+		}
+		k, err := input.Atoi(pos, n+1, len(line)-len(pos)+1, lineFormat)
+		if err != nil {
+			return nil, err
+		}
+		start = append(start, k)
+	}
+	return start, nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	start, err := ParseStart(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	// the puzzle is played by as many players as the input lists
+	g := PracticeGame
+	g.Players = len(start)
+	k, err := g.Deterministic(start)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(k), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	start, err := ParseStart(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	g := QuantumGame
+	g.Players = len(start)
+	wins, err := g.Universes(start)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	max := 0
+	for _, i := range wins {
+		if i > max {
+			max = i
+		}
+	}
+	return solver.Int(max), nil
+}
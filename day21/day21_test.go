@@ -0,0 +1,287 @@
+package day21
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+	"github.com/xorkevin/advent2021/internal/solvertest"
+)
+
+func TestSolve(t *testing.T) {
+	solvertest.Manifest(t, solver.Parts{Part1: Part1, Part2: Part2})
+}
+
+// naiveUniverses plays out every universe without memoization
+func naiveUniverses(g DiracGame, pos, score []int, turn int) []int {
+	wins := make([]int, g.Players)
+	var roll func(n, total int)
+	roll = func(n, total int) {
+		if n == g.Rolls {
+			p := append([]int{}, pos...)
+			s := append([]int{}, score...)
+			p[turn] = (p[turn] + total) % g.BoardSize
+			s[turn] += p[turn] + 1
+			if s[turn] >= g.WinScore {
+				wins[turn]++
+				return
+			}
+			for n, k := range naiveUniverses(g, p, s, (turn+1)%g.Players) {
+				wins[n] += k
+			}
+			return
+		}
+		for face := 1; face <= g.DieSides; face++ {
+			roll(n+1, total+face)
+		}
+	}
+	roll(0, 0)
+	return wins
+}
+
+func TestUniverses(t *testing.T) {
+	for _, tc := range []struct {
+		g     DiracGame
+		start []int
+	}{
+		{DiracGame{BoardSize: 10, WinScore: 8, DieSides: 3, Rolls: 3, Players: 2}, []int{1, 1}},
+		{DiracGame{BoardSize: 10, WinScore: 8, DieSides: 3, Rolls: 3, Players: 2}, []int{4, 2}},
+		{DiracGame{BoardSize: 5, WinScore: 12, DieSides: 2, Rolls: 2, Players: 2}, []int{4, 2}},
+		{DiracGame{BoardSize: 7, WinScore: 10, DieSides: 4, Rolls: 1, Players: 2}, []int{7, 3}},
+		{DiracGame{BoardSize: 10, WinScore: 7, DieSides: 3, Rolls: 2, Players: 3}, []int{4, 8, 1}},
+		{DiracGame{BoardSize: 6, WinScore: 8, DieSides: 2, Rolls: 2, Players: 4}, []int{1, 2, 3, 4}},
+		{DiracGame{BoardSize: 10, WinScore: 9, DieSides: 3, Rolls: 1, Players: 1}, []int{5}},
+	} {
+		pos := make([]int, len(tc.start))
+		for n, i := range tc.start {
+			pos[n] = i - 1
+		}
+		want := naiveUniverses(tc.g, pos, make([]int, len(pos)), 0)
+		got, err := tc.g.Universes(tc.start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%+v from %v: want %v, got %v", tc.g, tc.start, want, got)
+		}
+	}
+
+	// the 2 player game of the puzzle example
+	wins, err := QuantumGame.Universes([]int{4, 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{444356092776315, 341960390180808}; !reflect.DeepEqual(wins, want) {
+		t.Errorf("want %v, got %v", want, wins)
+	}
+
+	if _, err := QuantumGame.Universes([]int{4}); !errors.Is(err, ErrPlayers) {
+		t.Errorf("want ErrPlayers, got %v", err)
+	}
+	if _, err := QuantumGame.Universes([]int{4, 11}); !errors.Is(err, ErrInvalidPosition) {
+		t.Errorf("want ErrInvalidPosition, got %v", err)
+	}
+	if _, err := (DiracGame{BoardSize: 10, WinScore: 21, Rolls: 3, Players: 2}).Universes([]int{4, 8}); !errors.Is(err, ErrInvalidGame) {
+		t.Errorf("want ErrInvalidGame, got %v", err)
+	}
+	if _, err := (DiracGame{BoardSize: 10, WinScore: 21, DieSides: 3, Rolls: 3, Players: MaxPlayers + 1}).Universes(make([]int, MaxPlayers+1)); !errors.Is(err, ErrInvalidGame) {
+		t.Errorf("want ErrInvalidGame, got %v", err)
+	}
+}
+
+// naiveOdds plays out every sequence of rolls of a fair die, returning the
+// chance each player wins and the expected number of turns
+func naiveOdds(g DiracGame, pos, score []int, turn int) ([]float64, float64) {
+	probs := make([]float64, g.Players)
+	turns := 1.0
+	p := 1 / math.Pow(float64(g.DieSides), float64(g.Rolls))
+	var roll func(n, total int)
+	roll = func(n, total int) {
+		if n == g.Rolls {
+			ps := append([]int{}, pos...)
+			s := append([]int{}, score...)
+			ps[turn] = (ps[turn] + total) % g.BoardSize
+			s[turn] += ps[turn] + 1
+			if s[turn] >= g.WinScore {
+				probs[turn] += p
+				return
+			}
+			k, e := naiveOdds(g, ps, s, (turn+1)%g.Players)
+			for n, i := range k {
+				probs[n] += p * i
+			}
+			turns += p * e
+			return
+		}
+		for face := 1; face <= g.DieSides; face++ {
+			roll(n+1, total+face)
+		}
+	}
+	roll(0, 0)
+	return probs, turns
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestAnalyze(t *testing.T) {
+	for _, tc := range []struct {
+		g     DiracGame
+		start []int
+	}{
+		{DiracGame{BoardSize: 10, WinScore: 8, DieSides: 3, Rolls: 3, Players: 2}, []int{4, 2}},
+		{DiracGame{BoardSize: 5, WinScore: 12, DieSides: 2, Rolls: 2, Players: 2}, []int{4, 2}},
+		{DiracGame{BoardSize: 10, WinScore: 7, DieSides: 3, Rolls: 2, Players: 3}, []int{4, 8, 1}},
+		{DiracGame{BoardSize: 10, WinScore: 9, DieSides: 3, Rolls: 1, Players: 1}, []int{5}},
+	} {
+		a, err := tc.g.Analyze(tc.start, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pos := make([]int, len(tc.start))
+		for n, i := range tc.start {
+			pos[n] = i - 1
+		}
+		wantProbs, wantTurns := naiveOdds(tc.g, pos, make([]int, len(pos)), 0)
+		for n, i := range wantProbs {
+			if !closeTo(a.WinProb[n], i) {
+				t.Errorf("%+v from %v: player %d want win prob %f, got %f", tc.g, tc.start, n+1, i, a.WinProb[n])
+			}
+		}
+		if k := sumProbs(a.WinProb); !closeTo(k, 1) {
+			t.Errorf("%+v from %v: win probs sum to %f", tc.g, tc.start, k)
+		}
+		if !closeTo(a.ExpectedTurns, wantTurns) {
+			t.Errorf("%+v from %v: want expected turns %f, got %f", tc.g, tc.start, wantTurns, a.ExpectedTurns)
+		}
+		want := naiveUniverses(tc.g, pos, make([]int, len(pos)), 0)
+		if !reflect.DeepEqual(a.Universes, want) {
+			t.Errorf("%+v from %v: want universes %v, got %v", tc.g, tc.start, want, a.Universes)
+		}
+
+		for n, c := range a.BestStart {
+			if len(c.Options) != tc.g.BoardSize {
+				t.Fatalf("%+v from %v: player %d has %d options", tc.g, tc.start, n+1, len(c.Options))
+			}
+			if !closeTo(c.Options[tc.start[n]-1].WinProb, a.WinProb[n]) {
+				t.Errorf("%+v from %v: player %d option at the start differs from the game", tc.g, tc.start, n+1)
+			}
+			for _, i := range c.Options {
+				if i.WinProb > c.Options[c.Best-1].WinProb {
+					t.Errorf("%+v from %v: player %d start %d beats best %d", tc.g, tc.start, n+1, i.Pos, c.Best)
+				}
+			}
+		}
+
+		first := a.States[0]
+		if first.Turn != 1 || !reflect.DeepEqual(first.Pos, tc.start) || !reflect.DeepEqual(first.Odds, a.Odds) {
+			t.Errorf("%+v from %v: first state %+v is not the start", tc.g, tc.start, first)
+		}
+		for _, i := range a.States {
+			if !closeTo(sumProbs(i.WinProb), 1) {
+				t.Errorf("%+v from %v: state %+v win probs do not sum to 1", tc.g, tc.start, i)
+			}
+		}
+	}
+}
+
+func sumProbs(a []float64) float64 {
+	k := 0.0
+	for _, i := range a {
+		k += i
+	}
+	return k
+}
+
+func TestDeterministic(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		win   int
+		start []int
+		want  int
+	}{
+		// the 2 player game of the puzzle example
+		{"example", 1000, []int{4, 8}, 745 * 993},
+		// player 1 scores 10, 14, 20 and player 2 scores 3, 9 over 15 rolls
+		{"two", 20, []int{4, 8}, 9 * 15},
+		// player 1 scores 10, 13, 16, 26, player 2 scores 3, 8, 12, and
+		// player 3 scores 5, 11, 15 over 30 rolls
+		{"three", 20, []int{4, 8, 1}, 12 * 30},
+	} {
+		g := PracticeGame
+		g.WinScore = tc.win
+		g.Players = len(tc.start)
+		k, err := g.Deterministic(tc.start)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if k != tc.want {
+			t.Errorf("%s: want %d, got %d", tc.name, tc.want, k)
+		}
+	}
+}
+
+func TestSolvePlayers(t *testing.T) {
+	text := "Player 1 starting position: 4\nPlayer 2 starting position: 8\nPlayer 3 starting position: 1\n"
+	a, err := Part1(strings.NewReader(text))
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := PracticeGame
+	g.Players = 3
+	want, err := g.Deterministic([]int{4, 8, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k, ok := a.Int(); !ok || k != want {
+		t.Errorf("want %d, got %s", want, a)
+	}
+}
+
+func TestParseStart(t *testing.T) {
+	start, err := ParseStart(strings.NewReader("Player 1 starting position: 4\nPlayer 2 starting position: 8\n\n\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(start, []int{4, 8}) {
+		t.Errorf("want [4 8], got %v", start)
+	}
+	if _, err := ParseStart(strings.NewReader("Player 1 starting position: 4\n\nPlayer 2 starting position: 8\n")); !errors.Is(err, ErrInvalidLine) {
+		t.Errorf("want error %v for a blank line between players, got %v", ErrInvalidLine, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		col  int
+		err  error
+	}{
+		{"Player 1 starting position: 4\nPlayer 2 starts at 8", 0, ErrInvalidLine},
+		{"Player 1 starting position: x", 29, input.ErrInvalidInt},
+		{"Player 2 starting position: 4", 8, ErrInvalidPlayer},
+	} {
+		_, err := Part2(strings.NewReader(tc.text))
+		var perr *input.ParseError
+		if !errors.As(err, &perr) || !errors.Is(err, tc.err) {
+			t.Errorf("%q: want parse error %v, got %v", tc.text, tc.err, err)
+			continue
+		}
+		if perr.Col != tc.col {
+			t.Errorf("%q: want col %d, got %d", tc.text, tc.col, perr.Col)
+		}
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.Bench(b, Part1, "input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.Bench(b, Part2, "input.txt")
+}
@@ -1,21 +1,25 @@
-package main
+package day22
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"errors"
+	"io"
 	"regexp"
 	"sort"
-	"strconv"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidLine = errors.New("Invalid line")
 )
 
 const (
-	puzzleInput = "input.txt"
+	lineFormat = "<on|off> x=int..int,y=int..int,z=int..int"
 )
 
 var (
-	lineFormat = regexp.MustCompile(`^(on|off) x=(-?\d+)\.\.(-?\d+),y=(-?\d+)\.\.(-?\d+),z=(-?\d+)\.\.(-?\d+)$`)
+	lineRegex = regexp.MustCompile(`^(on|off) x=(-?\d+)\.\.(-?\d+),y=(-?\d+)\.\.(-?\d+),z=(-?\d+)\.\.(-?\d+)$`)
 )
 
 type (
@@ -191,52 +195,32 @@ func calculateOnX(zones []Zone) int {
 	return volume
 }
 
-func main() {
-	file, err := os.Open(puzzleInput)
+func parse(r io.Reader) ([]Zone, []Zone, error) {
+	lines, err := input.Lines(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, nil, err
 	}
-	defer func() {
-		if err := file.Close(); err != nil {
-			log.Fatal(err)
-		}
-	}()
 
 	var zones1 []Zone
 	var zones2 []Zone
 
-	scanner := bufio.NewScanner(file)
 	prioCounter := 0
-	for scanner.Scan() {
-		m := lineFormat.FindStringSubmatch(scanner.Text())
+	for n, line := range lines {
+		m := lineRegex.FindStringSubmatchIndex(line)
 		if len(m) == 0 {
-			log.Fatalln("Invalid line")
-		}
-		on := m[1] == "on"
-		x1, err := strconv.Atoi(m[2])
-		if err != nil {
-			log.Fatalln(err)
-		}
-		x2, err := strconv.Atoi(m[3])
-		if err != nil {
-			log.Fatalln(err)
-		}
-		y1, err := strconv.Atoi(m[4])
-		if err != nil {
-			log.Fatalln(err)
-		}
-		y2, err := strconv.Atoi(m[5])
-		if err != nil {
-			log.Fatalln(err)
+			return nil, nil, input.NewParseError(n+1, 0, lineFormat, ErrInvalidLine)
 		}
-		z1, err := strconv.Atoi(m[6])
-		if err != nil {
-			log.Fatalln(err)
-		}
-		z2, err := strconv.Atoi(m[7])
-		if err != nil {
-			log.Fatalln(err)
+		on := line[m[2]:m[3]] == "on"
+		var k [6]int
+		for j := range k {
+			start, end := m[2*j+4], m[2*j+5]
+			v, err := input.Atoi(line[start:end], n+1, start+1, lineFormat)
+			if err != nil {
+				return nil, nil, err
+			}
+			k[j] = v
 		}
+		x1, x2, y1, y2, z1, z2 := k[0], k[1], k[2], k[3], k[4], k[5]
 		if x1 > 50 || x1 < -50 {
 			zones2 = append(zones2, Zone{prioCounter, on, x1, x2, y1, y2, z1, z2})
 		} else {
@@ -245,11 +229,21 @@ func main() {
 		prioCounter++
 	}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+	return zones1, zones2, nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	zones1, _, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
 	}
+	return solver.Int(calculateOnX(zones1)), nil
+}
 
-	part1 := calculateOnX(zones1)
-	fmt.Println("Part 1:", part1)
-	fmt.Println("Part 2:", part1+calculateOnX(zones2))
+func Part2(r io.Reader) (solver.Answer, error) {
+	zones1, zones2, err := parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(calculateOnX(zones1) + calculateOnX(zones2)), nil
 }
@@ -0,0 +1,260 @@
+package day23
+
+import (
+	"io"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/search"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+type (
+	Vec2 struct {
+		x, y int
+	}
+
+	State [4][4]Vec2
+)
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func (v Vec2) Manhattan(o Vec2) int {
+	return abs(v.x-o.x) + abs(v.y-o.y)
+}
+
+func (s State) String() string {
+	grid := [][]byte{
+		[]byte("#############"),
+		[]byte("#...........#"),
+		[]byte("###.#.#.#.###"),
+		[]byte("  #.#.#.#.#"),
+		[]byte("  #.#.#.#.#"),
+		[]byte("  #.#.#.#.#"),
+		[]byte("  #########"),
+	}
+	for n, i := range s {
+		for _, j := range i {
+			if j.x == 0 {
+				continue
+			}
+			grid[j.y][j.x] = 'A' + byte(n)
+		}
+	}
+	b := strings.Builder{}
+	for _, i := range grid {
+		b.WriteString(string(i))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func (s State) Heuristic(num int) int {
+	k := 0
+	for n, i := range s {
+		for m, j := range i {
+			if m >= num {
+				continue
+			}
+			k += abs(j.x-winLocs[n]) * costs[n]
+		}
+	}
+	return k
+}
+
+var (
+	winLocs = [4]int{3, 5, 7, 9}
+	teamPos = [12]int{-1, -1, -1, 0, -1, 1, -1, 2, -1, 3, -1, -1}
+	costs   = [4]int{1, 10, 100, 1000}
+)
+
+func isWin(s State, num int) bool {
+	for n, i := range s {
+		for m, j := range i {
+			if m >= num {
+				continue
+			}
+			if j.x != winLocs[n] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isHallway(v Vec2) bool {
+	return v.y < 2
+}
+
+func genHallway(state State, num, base int) ([12]bool, [12]int, [4]bool) {
+	hall := [12]bool{}
+	depth := [12]int{}
+	clear := [4]bool{}
+	for i := range depth {
+		depth[i] = base
+	}
+	for i := range clear {
+		clear[i] = true
+	}
+	for n, i := range state {
+		for m, j := range i {
+			if m >= num {
+				continue
+			}
+			if isHallway(j) {
+				hall[j.x] = true
+			} else {
+				if j.y <= depth[j.x] {
+					depth[j.x] = j.y - 1
+				}
+				if j.x != winLocs[n] {
+					clear[teamPos[j.x]] = false
+				}
+			}
+		}
+	}
+	return hall, depth, clear
+}
+
+func hallwayClear(a, b int, hall [12]bool) bool {
+	origA := a
+	if a > b {
+		a, b = b, a
+	}
+	for i := a; i <= b; i++ {
+		if i == origA {
+			continue
+		}
+		if hall[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func getNeighbors(state State, num, base int) []search.Edge[State] {
+	var opts []search.Edge[State]
+	hallway, depth, clear := genHallway(state, num, base)
+	for n, i := range state {
+		for m, j := range i {
+			if m >= num {
+				continue
+			}
+			if isHallway(j) {
+				// once in hallway, must move into correct room, unless other kind has occupied
+				if !clear[n] {
+					continue
+				}
+				tx := winLocs[n]
+				if depth[tx] < 2 {
+					continue
+				}
+				if !hallwayClear(j.x, tx, hallway) {
+					continue
+				}
+				target := Vec2{
+					x: tx,
+					y: depth[tx],
+				}
+				k := state
+				k[n][m] = target
+				opts = append(opts, search.Edge[State]{
+					To:   k,
+					Cost: j.Manhattan(target) * costs[n],
+				})
+			} else {
+				// if not top, cannot move
+				if j.y != depth[j.x]+1 {
+					continue
+				}
+				// if clear and in winning position, no need to move
+				if clear[n] && j.x == winLocs[n] {
+					continue
+				}
+				for _, x := range []int{1, 2, 4, 6, 8, 10, 11} {
+					if !hallwayClear(j.x, x, hallway) {
+						continue
+					}
+					target := Vec2{
+						x: x,
+						y: 1,
+					}
+					k := state
+					k[n][m] = target
+					opts = append(opts, search.Edge[State]{
+						To:   k,
+						Cost: j.Manhattan(target) * costs[n],
+					})
+				}
+			}
+		}
+	}
+	return opts
+}
+
+func pathfind(start State, num, base int) int {
+	g := search.Graph[State]{
+		Neighbors: func(s State) []search.Edge[State] {
+			return getNeighbors(s, num, base)
+		},
+	}
+	return g.AStar(start, func(s State) bool {
+		return isWin(s, num)
+	}, func(s State) int {
+		return s.Heuristic(num)
+	}).Cost
+}
+
+func calcStart(lines []string) State {
+	s := State{}
+	for y, i := range lines {
+		for x, j := range []byte(i) {
+			switch j {
+			case 'A', 'B', 'C', 'D':
+			default:
+				continue
+			}
+			d := -1
+			for n, i := range s[j-'A'] {
+				if i.x == 0 {
+					d = n
+					break
+				}
+			}
+			s[j-'A'][d] = Vec2{x, y}
+		}
+	}
+	return s
+}
+
+func unfold(lines []string) []string {
+	if len(lines) < 3 {
+		return lines
+	}
+	k := make([]string, 0, len(lines)+2)
+	k = append(k, lines[:3]...)
+	k = append(k, "  #D#C#B#A#", "  #D#B#A#C#")
+	k = append(k, lines[3:]...)
+	return k
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(pathfind(calcStart(lines), 2, 3)), nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Int(pathfind(calcStart(unfold(lines)), 4, 5)), nil
+}
@@ -0,0 +1,79 @@
+package day24
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/alu"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+//go:generate go run ../cmd/alu gen input.txt --package day24 --func runInput --fold --dse -o input_gen_test.go
+
+var (
+	ErrWrongInput = errors.New("Wrong input")
+)
+
+// modelNumber solves for the largest or smallest valid model number, and
+// checks it by running the program. A program which is not MONAD is searched
+// instead.
+func modelNumber(r io.Reader, largest bool) (solver.Answer, error) {
+	prog, err := alu.Parse(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	monad, err := ParseMonad(prog)
+	var stdin []int
+	if err == nil {
+		stdin, err = monad.ModelNumber(largest)
+	}
+	if errors.Is(err, ErrNotMonad) {
+		stdin, err = Search(context.Background(), prog, monad, largest, 0)
+	}
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	m := alu.NewMachine(stdin)
+	if err := m.Run(prog); err != nil {
+		return solver.Answer{}, err
+	}
+	if z := m.Reg(alu.RegZ); z != 0 {
+		trace, _ := alu.TraceProgram(prog, stdin)
+		return solver.Answer{}, monad.rejectedBy(stdin, trace, z)
+	}
+	num := 0
+	for _, i := range stdin {
+		num = 10*num + i
+	}
+	return solver.Int(num), nil
+}
+
+// Search finds the largest or smallest valid model number of any program with
+// alu.Search, without assuming it is MONAD. The blocks of a program which
+// parses as MONAD, even one which is not solved by its constraints, may prune
+// the search. Workers less than 1 is the number of CPUs.
+func Search(ctx context.Context, prog alu.Program, monad Monad, largest bool, workers int) ([]int, error) {
+	opts := alu.SearchOptions{
+		Largest: largest,
+		Min:     minDigit,
+		Max:     maxDigit,
+		Workers: workers,
+	}
+	if monad != nil {
+		opts.Prune = monad.Prune(minDigit)
+	}
+	stdin, _, err := alu.Search(ctx, prog, opts)
+	if err != nil {
+		return nil, err
+	}
+	return stdin, nil
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	return modelNumber(r, true)
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	return modelNumber(r, false)
+}
@@ -0,0 +1,251 @@
+package day24
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/alu"
+	"github.com/xorkevin/advent2021/internal/solver"
+	"github.com/xorkevin/advent2021/internal/solvertest"
+)
+
+func TestSolve(t *testing.T) {
+	s := solver.Parts{Part1: Part1, Part2: Part2}
+	solvertest.Run(t, s, []solvertest.Case{
+		{
+			Name:  "not monad",
+			Text:  "inp w\ninp z\nadd z w\nadd z -5\n",
+			Part1: "41",
+			Part2: "14",
+		},
+		{
+			Name:  "div 52",
+			Text:  Monad{{1, 12, 4}, {52, -6, 3}}.Program().String(),
+			Part1: "97",
+			Part2: "31",
+		},
+	})
+	solvertest.Manifest(t, s)
+}
+
+// bruteForce runs the program on every model number, returning the largest and
+// smallest valid ones
+func bruteForce(t *testing.T, prog alu.Program) ([]int, []int) {
+	t.Helper()
+	var largest, smallest []int
+	digits := make([]int, prog.Inputs())
+	m := alu.NewMachine(nil)
+	var try func(n int)
+	try = func(n int) {
+		if n == len(digits) {
+			m.Reset(digits)
+			if err := m.Run(prog); err != nil {
+				t.Fatal(err)
+			}
+			if m.Reg(alu.RegZ) != 0 {
+				return
+			}
+			if smallest == nil {
+				smallest = append([]int{}, digits...)
+			}
+			largest = append(largest[:0], digits...)
+			return
+		}
+		for i := 1; i <= 9; i++ {
+			digits[n] = i
+			try(n + 1)
+		}
+	}
+	try(0)
+	return largest, smallest
+}
+
+func TestModelNumber(t *testing.T) {
+	for _, tc := range []Monad{
+		{{1, 12, 4}, {26, -6, 3}},
+		{{1, 10, 0}, {1, 13, 7}, {26, -9, 2}, {26, 0, 5}},
+		{{1, 14, 2}, {26, -3, 9}, {1, 11, 16}, {26, -10, 0}, {1, 10, 5}, {26, 4, 1}},
+		{{1, 12, 16}, {26, -6, 3}},
+	} {
+		prog := tc.Program()
+		m, err := ParseMonad(prog)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(m, tc) {
+			t.Errorf("want blocks %v, got %v", tc, m)
+		}
+		wantLargest, wantSmallest := bruteForce(t, prog)
+		largest, err := m.ModelNumber(true)
+		if wantLargest == nil {
+			if !errors.Is(err, ErrNoModel) {
+				t.Errorf("%v: want error %v, got %v", tc, ErrNoModel, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		smallest, err := m.ModelNumber(false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(largest, wantLargest) || !reflect.DeepEqual(smallest, wantSmallest) {
+			t.Errorf("%v: want %v and %v, got %v and %v", tc, wantLargest, wantSmallest, largest, smallest)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	for _, name := range []string{"input.txt", "input3.txt"} {
+		prog, err := alu.ParseFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := ParseMonad(prog)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, largest := range []bool{true, false} {
+			want, err := m.ModelNumber(largest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := Search(context.Background(), prog, m, largest, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%s largest %t: want %v, got %v", name, largest, want, got)
+			}
+		}
+	}
+
+	// blocks which are not solved by their constraints are searched
+	for _, tc := range []Monad{
+		{{1, 5, 4}, {26, -6, 3}},
+		{{26, -6, 3}, {1, 12, 4}, {26, -10, 2}},
+		{{1, 12, 30}, {26, -6, 3}},
+		{{1, 12, 20}, {1, 14, 2}, {26, -1, 0}, {26, 10, 1}},
+		// divisors other than 26 shrink z too
+		{{1, 12, 4}, {52, -6, 3}},
+		{{1, 12, 4}, {1, 10, 2}, {5, -3, 1}, {26, -6, 3}},
+	} {
+		if _, err := tc.Constraints(); !errors.Is(err, ErrNotMonad) {
+			t.Fatalf("%v: want error %v, got %v", tc, ErrNotMonad, err)
+		}
+		prog := tc.Program()
+		wantLargest, wantSmallest := bruteForce(t, prog)
+		for _, largest := range []bool{true, false} {
+			want := wantSmallest
+			if largest {
+				want = wantLargest
+			}
+			got, err := Search(context.Background(), prog, tc, largest, 0)
+			if want == nil {
+				if !errors.Is(err, alu.ErrNoSolution) {
+					t.Errorf("%v: want error %v, got %v and %v", tc, alu.ErrNoSolution, got, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("%v largest %t: want %v, got %v", tc, largest, want, got)
+			}
+		}
+	}
+	if (Monad{{1, 12, -3}}).Prune(minDigit) != nil {
+		t.Error("want no prune for a negative push")
+	}
+	if (Monad{{1, 12, 4}, {0, -6, 3}}).Prune(minDigit) != nil {
+		t.Error("want no prune for a divisor of 0")
+	}
+}
+
+func TestGenerated(t *testing.T) {
+	prog, err := alu.ParseFile("input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(24))
+	stdin := make([]int, prog.Inputs())
+	m := alu.NewMachine(nil)
+	for n := 0; n < 1000; n++ {
+		for i := range stdin {
+			stdin[i] = rng.Intn(9) + 1
+		}
+		m.Reset(stdin)
+		if err := m.Run(prog); err != nil {
+			t.Fatal(err)
+		}
+		w, x, y, z := runInput(stdin)
+		if got := [alu.NumRegs]int{w, x, y, z}; got != m.Regs() {
+			t.Fatalf("%v: want %v, got %v", stdin, m.Regs(), got)
+		}
+	}
+}
+
+func TestRejectedBy(t *testing.T) {
+	m := Monad{{1, 12, 4}, {1, 10, 8}, {26, -6, 3}, {26, -2, 1}}
+	for _, tc := range []struct {
+		stdin []int
+		want  string
+	}{
+		{[]int{5, 1, 3, 8}, "[5 1 3 8] is rejected by block 4, which leaves z as 9 instead of 0"},
+		{[]int{5, 1, 5, 7}, "[5 1 5 7] is rejected by block 3, which leaves z as 242 instead of 9"},
+	} {
+		trace, err := alu.TraceProgram(m.Program(), tc.stdin)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = m.rejectedBy(tc.stdin, trace, trace[len(trace)-1].Regs[alu.RegZ])
+		if !errors.Is(err, ErrWrongInput) || !strings.HasSuffix(err.Error(), tc.want) {
+			t.Errorf("want error %q, got %v", tc.want, err)
+		}
+	}
+}
+
+func TestNotMonad(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		prog alu.Program
+	}{
+		{"example", alu.Program{
+			{Op: alu.OpInp, Arg: [2]alu.Arg{alu.Reg(alu.RegZ)}},
+			{Op: alu.OpInp, Arg: [2]alu.Arg{alu.Reg(alu.RegX)}},
+			{Op: alu.OpMul, Arg: [2]alu.Arg{alu.Reg(alu.RegZ), alu.Imm(3)}},
+			{Op: alu.OpEql, Arg: [2]alu.Arg{alu.Reg(alu.RegZ), alu.Reg(alu.RegX)}},
+		}},
+		{"push matchable", Monad{{1, 5, 4}, {26, -6, 3}}.Program()},
+		{"pop empty", Monad{{26, -6, 3}, {1, 12, 4}}.Program()},
+		{"unpopped", Monad{{1, 12, 4}}.Program()},
+		{"div", Monad{{2, 12, 4}, {26, -6, 3}}.Program()},
+		{"register arg", func() alu.Program {
+			prog := Monad{{1, 12, 4}, {26, -6, 3}}.Program()
+			prog[5].Arg[1] = alu.Reg(alu.RegY)
+			return prog
+		}()},
+	} {
+		m, err := ParseMonad(tc.prog)
+		if err == nil {
+			_, err = m.Constraints()
+		}
+		if !errors.Is(err, ErrNotMonad) {
+			t.Errorf("%s: want error %v, got %v", tc.name, ErrNotMonad, err)
+		}
+	}
+}
+
+func BenchmarkPart1(b *testing.B) {
+	solvertest.Bench(b, Part1, "input.txt")
+}
+
+func BenchmarkPart2(b *testing.B) {
+	solvertest.Bench(b, Part2, "input.txt")
+}
@@ -0,0 +1,304 @@
+// Code generated by alu gen; DO NOT EDIT.
+
+package day24
+
+// runInput runs an ALU program of 252 instructions on 14 inputs, and
+// returns the final value of each register
+func runInput(stdin []int) (w, x, y, z int) {
+	_ = stdin[13]
+	w = stdin[0]
+	if w == 10 {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = w
+	y += 2
+	y *= x
+	z = y
+	w = stdin[1]
+	x = z
+	x %= 26
+	x += 15
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 16
+	y *= x
+	z += y
+	w = stdin[2]
+	x = z
+	x %= 26
+	x += 14
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 9
+	y *= x
+	z += y
+	w = stdin[3]
+	x = z
+	x %= 26
+	x += 15
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y *= x
+	z += y
+	w = stdin[4]
+	x = z
+	x %= 26
+	z /= 26
+	x -= 8
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 1
+	y *= x
+	z += y
+	w = stdin[5]
+	x = z
+	x %= 26
+	x += 10
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 12
+	y *= x
+	z += y
+	w = stdin[6]
+	x = z
+	x %= 26
+	z /= 26
+	x -= 16
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 6
+	y *= x
+	z += y
+	w = stdin[7]
+	x = z
+	x %= 26
+	z /= 26
+	x -= 4
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 6
+	y *= x
+	z += y
+	w = stdin[8]
+	x = z
+	x %= 26
+	x += 11
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 3
+	y *= x
+	z += y
+	w = stdin[9]
+	x = z
+	x %= 26
+	z /= 26
+	x -= 3
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 5
+	y *= x
+	z += y
+	w = stdin[10]
+	x = z
+	x %= 26
+	x += 12
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 9
+	y *= x
+	z += y
+	w = stdin[11]
+	x = z
+	x %= 26
+	z /= 26
+	x -= 7
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 3
+	y *= x
+	z += y
+	w = stdin[12]
+	x = z
+	x %= 26
+	z /= 26
+	x -= 15
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 2
+	y *= x
+	z += y
+	w = stdin[13]
+	x = z
+	x %= 26
+	z /= 26
+	x -= 7
+	if x == w {
+		x = 1
+	} else {
+		x = 0
+	}
+	if x == 0 {
+		x = 1
+	} else {
+		x = 0
+	}
+	y = 25 * x
+	y += 1
+	z *= y
+	y = w
+	y += 3
+	y *= x
+	z += y
+	return w, x, y, z
+}
@@ -0,0 +1,238 @@
+package day24
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/alu"
+)
+
+var (
+	ErrNotMonad = errors.New("Not a MONAD program")
+	ErrNoModel  = errors.New("No valid model number")
+)
+
+// blockSource is the block of MONAD which checks a digit, where the args of
+// div z, add x, and add y vary between blocks. z holds a stack of base 26
+// digits. A block pushes its digit plus an offset, unless it divides z by 26 to
+// pop the top of the stack, and the popped value plus the check equals its
+// digit.
+const blockSource = `inp w
+mul x 0
+add x z
+mod x 26
+div z 0
+add x 0
+eql x w
+eql x 0
+mul y 0
+add y 25
+mul y x
+add y 1
+mul z y
+mul y 0
+add y w
+add y 0
+mul y x
+add z y
+`
+
+const (
+	blockDivIndex    = 4
+	blockCheckIndex  = 5
+	blockOffsetIndex = 15
+
+	divPush = 1
+	divPop  = 26
+
+	minDigit = 1
+	maxDigit = 9
+)
+
+var blockTemplate = func() alu.Program {
+	prog, err := alu.Parse(strings.NewReader(blockSource))
+	if err != nil {
+		panic(err)
+	}
+	return prog
+}()
+
+type (
+	// Block is the args of a block of MONAD which vary
+	Block struct {
+		// Div is 1 for a block which pushes its digit, and 26 for a block which
+		// pops
+		Div int
+		// Check is added to the top of the stack to compare with the digit
+		Check int
+		// Offset is added to the digit to push
+		Offset int
+	}
+
+	// Monad is a MONAD program as its blocks, one for each digit
+	Monad []Block
+
+	// Constraint is a pair of digits where the digit at Pop must equal the
+	// digit at Push plus Diff for z to be 0 at the end
+	Constraint struct {
+		Push int
+		Pop  int
+		Diff int
+	}
+)
+
+// ParseMonad returns the blocks of a program which must be a MONAD program
+func ParseMonad(prog alu.Program) (Monad, error) {
+	size := len(blockTemplate)
+	if len(prog) == 0 || len(prog)%size != 0 {
+		return nil, fmt.Errorf("%w: %d instructions is not a multiple of a block of %d", ErrNotMonad, len(prog), size)
+	}
+	m := make(Monad, 0, len(prog)/size)
+	for k := 0; k < len(prog); k += size {
+		var b Block
+		for n, want := range blockTemplate {
+			got := prog[k+n]
+			ok := got.Op == want.Op && got.Arg[0] == want.Arg[0]
+			switch n {
+			case blockDivIndex:
+				b.Div = got.Arg[1].Val
+				ok = ok && got.Arg[1].Imm
+			case blockCheckIndex:
+				b.Check = got.Arg[1].Val
+				ok = ok && got.Arg[1].Imm
+			case blockOffsetIndex:
+				b.Offset = got.Arg[1].Val
+				ok = ok && got.Arg[1].Imm
+			default:
+				ok = ok && got.Arg[1] == want.Arg[1]
+			}
+			if !ok {
+				return nil, fmt.Errorf("%w: instruction %d %q does not match %q", ErrNotMonad, k+n+1, got.String(), want.String())
+			}
+		}
+		m = append(m, b)
+	}
+	return m, nil
+}
+
+// Program returns the MONAD program of the blocks
+func (m Monad) Program() alu.Program {
+	prog := make(alu.Program, 0, len(m)*len(blockTemplate))
+	for _, b := range m {
+		k := len(prog)
+		prog = append(prog, blockTemplate...)
+		prog[k+blockDivIndex].Arg[1] = alu.Imm(b.Div)
+		prog[k+blockCheckIndex].Arg[1] = alu.Imm(b.Check)
+		prog[k+blockOffsetIndex].Arg[1] = alu.Imm(b.Offset)
+	}
+	return prog
+}
+
+// Constraints pairs each block which pushes with the block which pops its
+// digit. Every model number which satisfies the constraints is valid, and
+// every other is not.
+func (m Monad) Constraints() ([]Constraint, error) {
+	var cons []Constraint
+	var stack []int
+	for n, b := range m {
+		// a pushed value must be a nonzero base 26 digit, or else z would not
+		// hold the stack
+		if b.Offset+minDigit < 1 || b.Offset+maxDigit >= divPop {
+			return nil, fmt.Errorf("%w: block %d pushes a digit with offset %d out of range", ErrNotMonad, n+1, b.Offset)
+		}
+		switch b.Div {
+		case divPush:
+			// the top of the stack is at least 0 and at most 25, so a digit
+			// must never be able to match it
+			if b.Check >= minDigit-(divPop-1) && b.Check <= maxDigit {
+				return nil, fmt.Errorf("%w: block %d pushes with check %d which a digit may match", ErrNotMonad, n+1, b.Check)
+			}
+			stack = append(stack, n)
+		case divPop:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("%w: block %d pops an empty stack", ErrNotMonad, n+1)
+			}
+			push := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			cons = append(cons, Constraint{
+				Push: push,
+				Pop:  n,
+				Diff: m[push].Offset + b.Check,
+			})
+		default:
+			return nil, fmt.Errorf("%w: block %d divides z by %d", ErrNotMonad, n+1, b.Div)
+		}
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("%w: %d digits are never popped", ErrNotMonad, len(stack))
+	}
+	return cons, nil
+}
+
+// Prune returns a prune for alu.Search which stops once z is at least the
+// product of the divisors of the blocks left. Each block leaves at least z
+// divided by its divisor when the divisor is positive and every value added
+// is non-negative, so it is nil if a divisor is not positive or a digit of at
+// least min plus some offset may be negative.
+func (m Monad) Prune(min int) func(block int, regs [alu.NumRegs]int) bool {
+	for _, i := range m {
+		if i.Div < 1 || i.Offset+min < 0 {
+			return nil
+		}
+	}
+	limits := make([]int, len(m))
+	limit := 1
+	for n := len(m) - 1; n >= 0; n-- {
+		if limit > math.MaxInt/m[n].Div {
+			limit = math.MaxInt
+		} else {
+			limit *= m[n].Div
+		}
+		limits[n] = limit
+	}
+	return func(block int, regs [alu.NumRegs]int) bool {
+		return regs[alu.RegZ] >= limits[block]
+	}
+}
+
+// ModelNumber returns the digits of the largest or smallest valid model number
+func (m Monad) ModelNumber(largest bool) ([]int, error) {
+	cons, err := m.Constraints()
+	if err != nil {
+		return nil, err
+	}
+	digits := make([]int, len(m))
+	for _, i := range cons {
+		if i.Diff > maxDigit-minDigit || i.Diff < minDigit-maxDigit {
+			return nil, fmt.Errorf("%w: digit %d must be digit %d plus %d", ErrNoModel, i.Pop+1, i.Push+1, i.Diff)
+		}
+		// each digit of a pair is as large or as small as possible while the
+		// other stays within range
+		if largest {
+			digits[i.Push] = maxDigit
+			if i.Diff > 0 {
+				digits[i.Push] = maxDigit - i.Diff
+			}
+		} else {
+			digits[i.Push] = minDigit
+			if i.Diff < 0 {
+				digits[i.Push] = minDigit - i.Diff
+			}
+		}
+		digits[i.Pop] = digits[i.Push] + i.Diff
+	}
+	return digits, nil
+}
+
+// rejectedBy returns an error naming the first block which pops without
+// dividing z by 26, and so keeps z from returning to 0
+func (m Monad) rejectedBy(stdin []int, trace []alu.Step, z int) error {
+	for n, i := range alu.SummarizeDigits(trace) {
+		if n < len(m) && m[n].Div == divPop && i.ZOut != i.ZIn/divPop {
+			return fmt.Errorf("%w: %v is rejected by block %d, which leaves z as %d instead of %d", ErrWrongInput, stdin, n+1, i.ZOut, i.ZIn/divPop)
+		}
+	}
+	return fmt.Errorf("%w: %v leaves z as %d", ErrWrongInput, stdin, z)
+}
@@ -0,0 +1,76 @@
+package day25
+
+import (
+	"errors"
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/grid"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidCell = errors.New("Invalid cell")
+)
+
+const (
+	rowFormat = "cells of >, v, or ."
+)
+
+var (
+	right = grid.Point{X: 1, Y: 0}
+	down  = grid.Point{X: 0, Y: 1}
+)
+
+func move(g *grid.Dense[byte], herd byte, dir grid.Point) (*grid.Dense[byte], bool) {
+	next := g.Clone()
+	changed := false
+	g.Range(func(p grid.Point, v byte) {
+		if v != herd {
+			return
+		}
+		k := g.Wrap(p.Add(dir))
+		if g.Get(k) != '.' {
+			return
+		}
+		next.Set(p, '.')
+		next.Set(k, herd)
+		changed = true
+	})
+	return next, changed
+}
+
+func step(g *grid.Dense[byte]) (*grid.Dense[byte], bool) {
+	g, movedEast := move(g, '>', right)
+	g, movedSouth := move(g, 'v', down)
+	return g, movedEast || movedSouth
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	rows, err := input.Grid(r)
+	if err != nil {
+		return solver.Answer{}, err
+	}
+	g, err := grid.Parse(rows, rowFormat, func(c byte) (byte, error) {
+		switch c {
+		case '>', 'v', '.':
+			return c, nil
+		default:
+			return 0, ErrInvalidCell
+		}
+	})
+	if err != nil {
+		return solver.Answer{}, err
+	}
+
+	iter := 0
+	for {
+		iter++
+		var changed bool
+		g, changed = step(g)
+		if !changed {
+			break
+		}
+	}
+	return solver.Int(iter), nil
+}
@@ -1,5 +1,5 @@
 module github.com/xorkevin/advent2021
 
-go 1.17
+go 1.18
 
 require xorkevin.dev/gnom v0.0.0-20201221224253-b2231bd0eef1 // indirect
@@ -0,0 +1,237 @@
+// Package alu parses, prints, and runs programs for the arithmetic logic unit
+// of day 24
+package alu
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+)
+
+var (
+	ErrInvalidLine = errors.New("Invalid line")
+	ErrInvalidOp   = errors.New("Invalid op")
+	ErrInvalidReg  = errors.New("Invalid register")
+	ErrArgs        = errors.New("Wrong number of args")
+	ErrNoInput     = errors.New("Out of input")
+	ErrDivZero     = errors.New("Division by zero")
+	ErrInvalidMod  = errors.New("Modulo of a negative or by a non-positive")
+)
+
+const (
+	lineFormat = "inp <w|x|y|z> or <add|mul|div|mod|eql|set|neq> <w|x|y|z> <w|x|y|z|int>"
+)
+
+type (
+	// Op is the operation of an instruction
+	Op int
+
+	// Arg is an instruction argument, which is either a register or an
+	// immediate value
+	Arg struct {
+		Imm bool
+		// Val is the immediate value, or the index of the register
+		Val int
+	}
+
+	// Instr is an instruction, whose first arg is always a register. Inp
+	// instructions only have the first arg.
+	Instr struct {
+		Op  Op
+		Arg [2]Arg
+	}
+
+	// Program is a list of instructions run in order
+	Program []Instr
+)
+
+const (
+	OpInp Op = iota
+	OpAdd
+	OpMul
+	OpDiv
+	OpMod
+	OpEql
+	// OpSet and OpNeq extend the ALU for optimized programs. Set copies b to
+	// a, and neq stores 1 in a if a and b are not equal and 0 otherwise.
+	OpSet
+	OpNeq
+	// numOps is the number of ops
+	numOps
+)
+
+const (
+	RegW = iota
+	RegX
+	RegY
+	RegZ
+	// NumRegs is the number of registers
+	NumRegs
+)
+
+var opNames = [...]string{
+	OpInp: "inp",
+	OpAdd: "add",
+	OpMul: "mul",
+	OpDiv: "div",
+	OpMod: "mod",
+	OpEql: "eql",
+	OpSet: "set",
+	OpNeq: "neq",
+}
+
+func (o Op) String() string {
+	if o < 0 || int(o) >= len(opNames) {
+		return "op(" + strconv.Itoa(int(o)) + ")"
+	}
+	return opNames[o]
+}
+
+// NumArgs returns the number of args taken by the op
+func (o Op) NumArgs() int {
+	if o == OpInp {
+		return 1
+	}
+	return 2
+}
+
+// Reg returns a register arg
+func Reg(r int) Arg {
+	return Arg{Imm: false, Val: r}
+}
+
+// Imm returns an immediate arg
+func Imm(v int) Arg {
+	return Arg{Imm: true, Val: v}
+}
+
+// RegName returns the name of a register
+func RegName(r int) string {
+	return string(rune('w' + r))
+}
+
+func (a Arg) String() string {
+	if a.Imm {
+		return strconv.Itoa(a.Val)
+	}
+	return RegName(a.Val)
+}
+
+func (i Instr) String() string {
+	b := strings.Builder{}
+	b.WriteString(i.Op.String())
+	for _, a := range i.Arg[:i.Op.NumArgs()] {
+		b.WriteByte(' ')
+		b.WriteString(a.String())
+	}
+	return b.String()
+}
+
+// String returns the program as source, with one instruction per line
+func (p Program) String() string {
+	b := strings.Builder{}
+	for _, i := range p {
+		b.WriteString(i.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Inputs returns the number of inputs read by the program
+func (p Program) Inputs() int {
+	k := 0
+	for _, i := range p {
+		if i.Op == OpInp {
+			k++
+		}
+	}
+	return k
+}
+
+func parseOp(s string) (Op, bool) {
+	for n, i := range opNames {
+		if s == i {
+			return Op(n), true
+		}
+	}
+	return 0, false
+}
+
+func parseReg(s string) (int, bool) {
+	if len(s) != 1 || s[0] < 'w' || s[0] > 'z' {
+		return 0, false
+	}
+	return int(s[0] - 'w'), true
+}
+
+// ParseInstr parses an instruction on the 1-indexed line of a program
+func ParseInstr(line string, lineno int) (Instr, error) {
+	arr, cols := input.Fields(line)
+	if len(arr) == 0 {
+		return Instr{}, input.NewParseError(lineno, 0, lineFormat, ErrInvalidLine)
+	}
+	op, ok := parseOp(arr[0])
+	if !ok {
+		return Instr{}, input.NewParseError(lineno, cols[0], lineFormat, fmt.Errorf("%w: %s", ErrInvalidOp, arr[0]))
+	}
+	if k := len(arr) - 1; k != op.NumArgs() {
+		return Instr{}, input.NewParseError(lineno, cols[0], lineFormat, fmt.Errorf("%w: %s takes %d, got %d", ErrArgs, op, op.NumArgs(), k))
+	}
+	instr := Instr{
+		Op: op,
+	}
+	for n, i := range arr[1:] {
+		if r, ok := parseReg(i); ok {
+			instr.Arg[n] = Reg(r)
+			continue
+		}
+		if n == 0 {
+			return Instr{}, input.NewParseError(lineno, cols[1], lineFormat, fmt.Errorf("%w: %s", ErrInvalidReg, i))
+		}
+		num, err := input.Atoi(i, lineno, cols[n+1], lineFormat)
+		if err != nil {
+			return Instr{}, err
+		}
+		instr.Arg[n] = Imm(num)
+	}
+	return instr, nil
+}
+
+// Parse reads a program with one instruction per line
+func Parse(r io.Reader) (Program, error) {
+	lines, err := input.Lines(r)
+	if err != nil {
+		return nil, err
+	}
+	prog := make(Program, 0, len(lines))
+	for n, line := range lines {
+		instr, err := ParseInstr(line, n+1)
+		if err != nil {
+			return nil, err
+		}
+		prog = append(prog, instr)
+	}
+	return prog, nil
+}
+
+// ParseFile reads a program from the named file, or stdin if it is -
+func ParseFile(name string) (_ Program, retErr error) {
+	file, err := input.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	prog, err := Parse(file)
+	if err != nil {
+		return nil, input.WithFile(err, name)
+	}
+	return prog, nil
+}
@@ -0,0 +1,122 @@
+package alu
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/input"
+)
+
+func run(t *testing.T, src string, stdin ...int) *Machine {
+	t.Helper()
+	prog, err := Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := NewMachine(stdin)
+	if err := m.Run(prog); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestRun(t *testing.T) {
+	if k := run(t, "inp x\nmul x -1\n", 7).Reg(RegX); k != -7 {
+		t.Errorf("negate: want -7, got %d", k)
+	}
+	triple := "inp z\ninp x\nmul z 3\neql z x\n"
+	if k := run(t, triple, 3, 9).Reg(RegZ); k != 1 {
+		t.Errorf("triple: want 1, got %d", k)
+	}
+	if k := run(t, triple, 3, 8).Reg(RegZ); k != 0 {
+		t.Errorf("triple: want 0, got %d", k)
+	}
+	binary := "inp w\nadd z w\nmod z 2\ndiv w 2\nadd y w\nmod y 2\ndiv w 2\nadd x w\nmod x 2\ndiv w 2\nmod w 2\n"
+	if k := run(t, binary, 13).Regs(); k != [NumRegs]int{1, 1, 0, 1} {
+		t.Errorf("binary: want [1 1 0 1], got %v", k)
+	}
+	if k := run(t, "add x -7\ndiv x 2\n").Reg(RegX); k != -3 {
+		t.Errorf("div: want truncation to -3, got %d", k)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	b, err := os.ReadFile("../../day24/input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog, err := Parse(strings.NewReader(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prog.Inputs() != 14 {
+		t.Errorf("want 14 inputs, got %d", prog.Inputs())
+	}
+	if s := prog.String(); s != string(b) {
+		t.Errorf("disassembly differs from source:\n%s", s)
+	}
+	again, err := Parse(strings.NewReader(prog.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.String() != prog.String() {
+		t.Error("program differs after a round trip")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		src  string
+		line int
+		col  int
+		err  error
+	}{
+		{"inp w\n\n", 2, 0, ErrInvalidLine},
+		{"inp w\nsub x 1", 2, 1, ErrInvalidOp},
+		{"add x", 1, 1, ErrArgs},
+		{"inp w x", 1, 1, ErrArgs},
+		{"add 1 x", 1, 5, ErrInvalidReg},
+		{"add  x  q", 1, 9, input.ErrInvalidInt},
+	} {
+		_, err := Parse(strings.NewReader(tc.src))
+		var perr *input.ParseError
+		if !errors.As(err, &perr) || !errors.Is(err, tc.err) {
+			t.Errorf("%q: want parse error %v, got %v", tc.src, tc.err, err)
+			continue
+		}
+		if perr.Line != tc.line || perr.Col != tc.col {
+			t.Errorf("%q: want %d:%d, got %d:%d", tc.src, tc.line, tc.col, perr.Line, perr.Col)
+		}
+	}
+}
+
+func TestExecErrors(t *testing.T) {
+	for _, tc := range []struct {
+		src   string
+		stdin []int
+		index int
+		err   error
+	}{
+		{"inp w\ninp x", []int{1}, 1, ErrNoInput},
+		{"add x 1\ndiv x y", nil, 1, ErrDivZero},
+		{"add x -1\nmod x 2", nil, 1, ErrInvalidMod},
+		{"mod x 0", nil, 0, ErrInvalidMod},
+	} {
+		prog, err := Parse(strings.NewReader(tc.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := NewMachine(tc.stdin)
+		err = m.Run(prog)
+		var eerr *ExecError
+		if !errors.As(err, &eerr) || !errors.Is(err, tc.err) {
+			t.Errorf("%q: want exec error %v, got %v", tc.src, tc.err, err)
+			continue
+		}
+		if eerr.Index != tc.index {
+			t.Errorf("%q: want index %d, got %d", tc.src, tc.index, eerr.Index)
+		}
+	}
+}
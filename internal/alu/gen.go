@@ -0,0 +1,285 @@
+package alu
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+)
+
+var (
+	ErrInvalidIdent = errors.New("Invalid identifier")
+)
+
+type (
+	// GenOptions configure the generated Go source of a program
+	GenOptions struct {
+		// Package is the package of the generated file
+		Package string
+		// Func is the name of the generated function
+		Func string
+		// Fold evaluates instructions whose args are known when generating,
+		// and simplifies adding 0, multiplying by 0 or 1, and dividing by 1
+		Fold bool
+		// DSE removes instructions which write a register that is not read
+		// before it is written again or returned. A removed instruction no
+		// longer crashes on a division by zero.
+		DSE bool
+	}
+
+	// stmt is an instruction of the generated function, whose args are
+	// either registers or values known when generating
+	stmt struct {
+		op  Op
+		dst int
+		a   Arg
+		b   Arg
+		// inp is the index of the input read by an inp
+		inp int
+	}
+)
+
+// eval computes an instruction of known values, and returns false if it would
+// crash
+func eval(op Op, a, b int) (int, bool) {
+	switch op {
+	case OpAdd:
+		return a + b, true
+	case OpMul:
+		return a * b, true
+	case OpDiv:
+		if b == 0 {
+			return 0, false
+		}
+		return a / b, true
+	case OpMod:
+		if a < 0 || b <= 0 {
+			return 0, false
+		}
+		return a % b, true
+	case OpEql:
+		if a == b {
+			return 1, true
+		}
+		return 0, true
+	case OpSet:
+		return b, true
+	case OpNeq:
+		if a != b {
+			return 1, true
+		}
+		return 0, true
+	default:
+		return 0, false
+	}
+}
+
+// crashes returns an error if an instruction with the args always crashes
+func crashes(op Op, a, b Arg) error {
+	switch op {
+	case OpDiv:
+		if b == Imm(0) {
+			return ErrDivZero
+		}
+	case OpMod:
+		if (a.Imm && a.Val < 0) || (b.Imm && b.Val <= 0) {
+			return ErrInvalidMod
+		}
+	}
+	return nil
+}
+
+// lower converts a program to statements, and returns the final value of each
+// register. When folding, registers with known values are substituted into
+// args, and instructions of known args produce no statement. Lowering stops at
+// the first instruction which always crashes, which is returned as an
+// ExecError.
+func lower(p Program, fold bool) ([]stmt, [NumRegs]Arg, error) {
+	var regs [NumRegs]Arg
+	for n := range regs {
+		regs[n] = Reg(n)
+		if fold {
+			regs[n] = Imm(0)
+		}
+	}
+	val := func(a Arg) Arg {
+		if a.Imm {
+			return a
+		}
+		return regs[a.Val]
+	}
+	var stmts []stmt
+	inp := 0
+	for n, i := range p {
+		dst := i.Arg[0].Val
+		if i.Op == OpInp {
+			stmts = append(stmts, stmt{op: OpInp, dst: dst, inp: inp})
+			inp++
+			regs[dst] = Reg(dst)
+			continue
+		}
+		a, b := val(i.Arg[0]), val(i.Arg[1])
+		if i.Op == OpSet {
+			// set does not read its destination
+			a = Imm(0)
+		}
+		if err := crashes(i.Op, a, b); err != nil {
+			return stmts, regs, &ExecError{Index: n, Instr: i, Err: err}
+		}
+		if fold {
+			if a.Imm && b.Imm {
+				if k, ok := eval(i.Op, a.Val, b.Val); ok {
+					regs[dst] = Imm(k)
+					continue
+				}
+			}
+			switch {
+			case i.Op == OpMul && (a == Imm(0) || b == Imm(0)):
+				regs[dst] = Imm(0)
+				continue
+			case (i.Op == OpAdd && b == Imm(0)) || ((i.Op == OpMul || i.Op == OpDiv) && b == Imm(1)):
+				continue
+			case (i.Op == OpAdd && a == Imm(0)) || (i.Op == OpMul && a == Imm(1)):
+				// adding to 0 or multiplying 1 is a copy
+				stmts = append(stmts, stmt{op: OpSet, dst: dst, a: Imm(0), b: b})
+				regs[dst] = Reg(dst)
+				continue
+			}
+			switch {
+			case !a.Imm && !b.Imm && a.Val != dst && b.Val == dst && (i.Op == OpAdd || i.Op == OpMul):
+				// keep the destination first so the statement may be
+				// generated as an assignment operation
+				a, b = b, a
+			case a.Imm && !b.Imm && (i.Op == OpEql || i.Op == OpNeq):
+				a, b = b, a
+			}
+		}
+		stmts = append(stmts, stmt{op: i.Op, dst: dst, a: a, b: b})
+		regs[dst] = Reg(dst)
+	}
+	return stmts, regs, nil
+}
+
+// eliminateDeadStores removes statements whose destination is written again
+// before it is read, or never read by the final value of any register
+func eliminateDeadStores(stmts []stmt, final [NumRegs]Arg) []stmt {
+	var live [NumRegs]bool
+	for _, i := range final {
+		if !i.Imm {
+			live[i.Val] = true
+		}
+	}
+	keep := make([]bool, len(stmts))
+	for n := len(stmts) - 1; n >= 0; n-- {
+		s := stmts[n]
+		if !live[s.dst] {
+			continue
+		}
+		keep[n] = true
+		live[s.dst] = false
+		if s.op == OpInp {
+			continue
+		}
+		for _, i := range [2]Arg{s.a, s.b} {
+			if !i.Imm {
+				live[i.Val] = true
+			}
+		}
+	}
+	k := stmts[:0]
+	for n, i := range stmts {
+		if keep[n] {
+			k = append(k, i)
+		}
+	}
+	return k
+}
+
+var opSymbols = [...]string{
+	OpAdd: "+",
+	OpMul: "*",
+	OpDiv: "/",
+	OpMod: "%",
+	OpEql: "==",
+	OpNeq: "!=",
+}
+
+func writeStmt(b *bytes.Buffer, s stmt) {
+	dst := RegName(s.dst)
+	switch {
+	case s.op == OpInp:
+		fmt.Fprintf(b, "%s = stdin[%d]\n", dst, s.inp)
+	case s.op == OpSet:
+		fmt.Fprintf(b, "%s = %s\n", dst, s.b)
+	case s.op == OpEql || s.op == OpNeq:
+		fmt.Fprintf(b, "if %s %s %s {\n%s = 1\n} else {\n%s = 0\n}\n", s.a, opSymbols[s.op], s.b, dst, dst)
+	case s.a == Reg(s.dst):
+		sym, arg := opSymbols[s.op], s.b
+		if s.op == OpAdd && arg.Imm && arg.Val < 0 && arg.Val != -arg.Val {
+			sym, arg = "-", Imm(-arg.Val)
+		}
+		fmt.Fprintf(b, "%s %s= %s\n", dst, sym, arg)
+	default:
+		fmt.Fprintf(b, "%s = %s %s %s\n", dst, s.a, opSymbols[s.op], s.b)
+	}
+}
+
+// Generate returns gofmt'd Go source of a function which runs the program on
+// its inputs and returns the final value of each register. The function
+// behaves as the program does for inputs which do not crash the machine.
+func Generate(p Program, opts GenOptions) ([]byte, error) {
+	if !token.IsIdentifier(opts.Package) {
+		return nil, fmt.Errorf("%w: package %q", ErrInvalidIdent, opts.Package)
+	}
+	if !token.IsIdentifier(opts.Func) {
+		return nil, fmt.Errorf("%w: func %q", ErrInvalidIdent, opts.Func)
+	}
+	for n, i := range p {
+		if i.Op < OpInp || i.Op >= numOps || i.Arg[0].Imm || i.Arg[0].Val < 0 || i.Arg[0].Val >= NumRegs {
+			return nil, fmt.Errorf("%w: instruction %d %q", ErrInvalidOp, n+1, i.String())
+		}
+	}
+	stmts, final, crash := lower(p, opts.Fold)
+	if opts.DSE {
+		if crash != nil {
+			// no register is read after a crash
+			final = [NumRegs]Arg{Imm(0), Imm(0), Imm(0), Imm(0)}
+		}
+		stmts = eliminateDeadStores(stmts, final)
+	}
+
+	b := &bytes.Buffer{}
+	b.WriteString("// Code generated by alu gen; DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "package %s\n\n", opts.Package)
+	inputs := p.Inputs()
+	fmt.Fprintf(b, "// %s runs an ALU program of %d instructions on %d inputs, and\n", opts.Func, len(p), inputs)
+	b.WriteString("// returns the final value of each register\n")
+	fmt.Fprintf(b, "func %s(stdin []int) (w, x, y, z int) {\n", opts.Func)
+	if inputs > 0 {
+		// bounds check every input up front, since dead reads may be removed
+		fmt.Fprintf(b, "_ = stdin[%d]\n", inputs-1)
+	}
+	for _, i := range stmts {
+		writeStmt(b, i)
+	}
+	if crash != nil {
+		fmt.Fprintf(b, "panic(%q)\n", crash.Error())
+	} else {
+		b.WriteString("return")
+		for n, i := range final {
+			if n > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteByte(' ')
+			b.WriteString(i.String())
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString("}\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to format generated source: %w", err)
+	}
+	return src, nil
+}
@@ -0,0 +1,266 @@
+package alu
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// randomProgram returns a program which may only crash on a mod of a negative
+func randomProgram(rng *rand.Rand, size int) Program {
+	prog := make(Program, 0, size)
+	for len(prog) < size {
+		op := Op(rng.Intn(int(numOps)))
+		instr := Instr{Op: op, Arg: [2]Arg{Reg(rng.Intn(NumRegs))}}
+		switch {
+		case op == OpDiv || op == OpMod:
+			instr.Arg[1] = Imm(rng.Intn(30) + 1)
+		case rng.Intn(2) == 0:
+			instr.Arg[1] = Reg(rng.Intn(NumRegs))
+		default:
+			instr.Arg[1] = Imm(rng.Intn(7) - 3)
+		}
+		prog = append(prog, instr)
+	}
+	return prog
+}
+
+func randomInputs(rng *rand.Rand, n int) []int {
+	stdin := make([]int, n)
+	for i := range stdin {
+		stdin[i] = rng.Intn(9) + 1
+	}
+	return stdin
+}
+
+// runStmts runs lowered statements as the generated function would
+func runStmts(stmts []stmt, final [NumRegs]Arg, stdin []int) [NumRegs]int {
+	var reg [NumRegs]int
+	val := func(a Arg) int {
+		if a.Imm {
+			return a.Val
+		}
+		return reg[a.Val]
+	}
+	for _, i := range stmts {
+		if i.op == OpInp {
+			reg[i.dst] = stdin[i.inp]
+			continue
+		}
+		a, b := val(i.a), val(i.b)
+		if i.op == OpMod {
+			// the generated function computes a negative mod rather than
+			// crash
+			reg[i.dst] = a % b
+			continue
+		}
+		k, _ := eval(i.op, a, b)
+		reg[i.dst] = k
+	}
+	var out [NumRegs]int
+	for n, i := range final {
+		out[n] = val(i)
+	}
+	return out
+}
+
+var genOptions = []GenOptions{
+	{},
+	{Fold: true},
+	{DSE: true},
+	{Fold: true, DSE: true},
+}
+
+func TestLower(t *testing.T) {
+	rng := rand.New(rand.NewSource(20))
+	m := NewMachine(nil)
+	for n := 0; n < 200; n++ {
+		prog := randomProgram(rng, 40)
+		for _, opts := range genOptions {
+			stmts, final, crash := lower(prog, opts.Fold)
+			if crash != nil {
+				// a folded crash must happen on every input
+				m.Reset(randomInputs(rng, prog.Inputs()))
+				if err := m.Run(prog); err == nil {
+					t.Fatalf("%+v: want crash %v\n%s", opts, crash, prog)
+				}
+				continue
+			}
+			if opts.DSE {
+				stmts = eliminateDeadStores(stmts, final)
+			}
+			for k := 0; k < 10; k++ {
+				stdin := randomInputs(rng, prog.Inputs())
+				m.Reset(stdin)
+				if err := m.Run(prog); err != nil {
+					continue
+				}
+				if got := runStmts(stmts, final, stdin); got != m.Regs() {
+					t.Fatalf("%+v on %v: want %v, got %v\n%s", opts, stdin, m.Regs(), got, prog)
+				}
+			}
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	prog, err := Parse(strings.NewReader("inp w\nmul x 0\nadd x z\nmod x 26\ndiv z 1\nadd x 10\neql x w\neql x 0\nmul y 0\nadd y 25\nmul y x\nadd y 1\nmul z y\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	stmts, final, _ := lower(prog, true)
+	stmts = eliminateDeadStores(stmts, final)
+	// x is known to be 10 before the first eql, and z stays 0
+	if final[RegZ] != Imm(0) {
+		t.Errorf("want z folded to 0, got %v", final[RegZ])
+	}
+	if len(stmts) != 5 {
+		t.Errorf("want 5 statements, got %d: %v", len(stmts), stmts)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	prog := Program{{Op: OpInp, Arg: [2]Arg{Reg(RegW)}}}
+	if _, err := Generate(prog, GenOptions{Package: "main", Func: "1run"}); !errors.Is(err, ErrInvalidIdent) {
+		t.Errorf("want error %v, got %v", ErrInvalidIdent, err)
+	}
+	prog = Program{{Op: OpAdd, Arg: [2]Arg{Imm(1), Imm(2)}}}
+	if _, err := Generate(prog, GenOptions{Package: "main", Func: "run"}); !errors.Is(err, ErrInvalidOp) {
+		t.Errorf("want error %v, got %v", ErrInvalidOp, err)
+	}
+}
+
+// TestGenerate compiles generated functions and checks them against the
+// interpreter
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping compiling generated code in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go not available")
+	}
+
+	input, err := ParseFile("../../day24/input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	crash, err := Parse(strings.NewReader("inp w\nadd x w\ndiv x 0\nadd z x\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(21))
+	progs := []Program{input, crash}
+	for n := 0; n < 20; n++ {
+		progs = append(progs, randomProgram(rng, 60))
+	}
+
+	tmp := t.TempDir()
+	main := &bytes.Buffer{}
+	main.WriteString("package main\n\nimport (\n\t\"encoding/json\"\n\t\"os\"\n)\n\n")
+	main.WriteString("var funcs = []func([]int) (int, int, int, int){\n")
+	type funcCase struct {
+		prog  Program
+		opts  GenOptions
+		stdin [][]int
+	}
+	var cases []funcCase
+	for n, prog := range progs {
+		for k, opts := range genOptions {
+			opts.Package = "main"
+			opts.Func = fmt.Sprintf("run%d_%d", n, k)
+			src, err := Generate(prog, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(tmp, opts.Func+".go"), src, 0644); err != nil {
+				t.Fatal(err)
+			}
+			fmt.Fprintf(main, "\t%s,\n", opts.Func)
+			c := funcCase{prog: prog, opts: opts}
+			for i := 0; i < 20; i++ {
+				c.stdin = append(c.stdin, randomInputs(rng, prog.Inputs()))
+			}
+			cases = append(cases, c)
+		}
+	}
+	main.WriteString(`}
+
+func main() {
+	var stdin [][][]int
+	if err := json.NewDecoder(os.Stdin).Decode(&stdin); err != nil {
+		panic(err)
+	}
+	out := make([][][4]int, len(stdin))
+	for n, f := range funcs {
+		for _, i := range stdin[n] {
+			var k [4]int
+			func() {
+				defer func() {
+					if recover() != nil {
+						k = [4]int{-1, -1, -1, -1}
+					}
+				}()
+				k[0], k[1], k[2], k[3] = f(i)
+			}()
+			out[n] = append(out[n], k)
+		}
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(out); err != nil {
+		panic(err)
+	}
+}
+`)
+	if err := os.WriteFile(filepath.Join(tmp, "main.go"), main.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "go.mod"), []byte("module gentest\n\ngo 1.18\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdin [][][]int
+	for _, i := range cases {
+		stdin = append(stdin, i.stdin)
+	}
+	in, err := json.Marshal(stdin)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(goBin, "run", ".")
+	cmd.Dir = tmp
+	cmd.Env = append(os.Environ(), "GOFLAGS=", "GOWORK=off")
+	cmd.Stdin = bytes.NewReader(in)
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+	b, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("%v: %s", err, stderr)
+	}
+	var out [][][NumRegs]int
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	m := NewMachine(nil)
+	for n, c := range cases {
+		for k, i := range c.stdin {
+			m.Reset(i)
+			if err := m.Run(c.prog); err != nil {
+				if errors.Is(err, ErrDivZero) && out[n][k] != [NumRegs]int{-1, -1, -1, -1} {
+					t.Errorf("%s on %v: want panic, got %v", c.opts.Func, i, out[n][k])
+				}
+				continue
+			}
+			if out[n][k] != m.Regs() {
+				t.Errorf("%s on %v: want %v, got %v", c.opts.Func, i, m.Regs(), out[n][k])
+			}
+		}
+	}
+}
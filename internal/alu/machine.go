@@ -0,0 +1,139 @@
+package alu
+
+import (
+	"fmt"
+)
+
+type (
+	// Machine is an ALU whose registers start at 0, and which reads its inputs
+	// in order
+	Machine struct {
+		reg   [NumRegs]int
+		stdin []int
+		inp   int
+	}
+
+	// ExecError is an instruction which crashed the machine
+	ExecError struct {
+		// Index is the 0-indexed position of the instruction in its program
+		Index int
+		Instr Instr
+		Err   error
+	}
+)
+
+func (e *ExecError) Error() string {
+	return fmt.Sprintf("instruction %d %q: %v", e.Index+1, e.Instr.String(), e.Err)
+}
+
+func (e *ExecError) Unwrap() error {
+	return e.Err
+}
+
+// NewMachine returns a machine which reads from stdin
+func NewMachine(stdin []int) *Machine {
+	return &Machine{
+		reg:   [NumRegs]int{},
+		stdin: stdin,
+		inp:   0,
+	}
+}
+
+// Reset zeroes the registers and reads from stdin
+func (m *Machine) Reset(stdin []int) {
+	m.reg = [NumRegs]int{}
+	m.stdin = stdin
+	m.inp = 0
+}
+
+// Reg returns the value of a register
+func (m *Machine) Reg(r int) int {
+	return m.reg[r]
+}
+
+// Regs returns the values of every register
+func (m *Machine) Regs() [NumRegs]int {
+	return m.reg
+}
+
+// InputsRead returns the number of inputs read so far
+func (m *Machine) InputsRead() int {
+	return m.inp
+}
+
+func (m *Machine) getArg(a Arg) int {
+	if a.Imm {
+		return a.Val
+	}
+	return m.reg[a.Val]
+}
+
+func (m *Machine) getInp() (int, error) {
+	if m.inp >= len(m.stdin) {
+		return 0, ErrNoInput
+	}
+	k := m.stdin[m.inp]
+	m.inp++
+	return k, nil
+}
+
+// Exec runs an instruction, leaving the registers unchanged if it crashes
+func (m *Machine) Exec(instr Instr) error {
+	dst := instr.Arg[0].Val
+	switch instr.Op {
+	case OpInp:
+		k, err := m.getInp()
+		if err != nil {
+			return err
+		}
+		m.reg[dst] = k
+	case OpAdd:
+		m.reg[dst] = m.getArg(instr.Arg[0]) + m.getArg(instr.Arg[1])
+	case OpMul:
+		m.reg[dst] = m.getArg(instr.Arg[0]) * m.getArg(instr.Arg[1])
+	case OpDiv:
+		b := m.getArg(instr.Arg[1])
+		if b == 0 {
+			return ErrDivZero
+		}
+		m.reg[dst] = m.getArg(instr.Arg[0]) / b
+	case OpMod:
+		a, b := m.getArg(instr.Arg[0]), m.getArg(instr.Arg[1])
+		if a < 0 || b <= 0 {
+			return fmt.Errorf("%w: %d mod %d", ErrInvalidMod, a, b)
+		}
+		m.reg[dst] = a % b
+	case OpEql:
+		k := 0
+		if m.getArg(instr.Arg[0]) == m.getArg(instr.Arg[1]) {
+			k = 1
+		}
+		m.reg[dst] = k
+	case OpSet:
+		m.reg[dst] = m.getArg(instr.Arg[1])
+	case OpNeq:
+		k := 0
+		if m.getArg(instr.Arg[0]) != m.getArg(instr.Arg[1]) {
+			k = 1
+		}
+		m.reg[dst] = k
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidOp, instr.Op)
+	}
+	return nil
+}
+
+// Run runs every instruction of a program in order, stopping at the first
+// which crashes with an ExecError
+func (m *Machine) Run(p Program) error {
+	for n, i := range p {
+		if err := m.Exec(i); err != nil {
+			return &ExecError{
+				Index: n,
+				Instr: i,
+				Err:   err,
+			}
+		}
+	}
+	return nil
+}
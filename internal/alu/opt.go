@@ -0,0 +1,254 @@
+package alu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidPass   = errors.New("Invalid pass")
+	ErrNotEquivalent = errors.New("Programs are not equivalent")
+)
+
+type (
+	// Pass rewrites a program into one which leaves the same values in the
+	// live registers for every input which crashes neither
+	Pass struct {
+		Name string
+		Run  func(p Program, live [NumRegs]bool) Program
+	}
+
+	// PassStats is the number of instructions before and after a pass
+	PassStats struct {
+		Name   string
+		Before int
+		After  int
+	}
+)
+
+// Passes are every optimization pass in the order they are run by default
+var Passes = []Pass{
+	{Name: "zero", Run: ZeroMul},
+	{Name: "constprop", Run: PropagateConstants},
+	{Name: "fuse", Run: FuseNeq},
+	{Name: "dse", Run: EliminateDeadStores},
+}
+
+// LookupPasses returns the passes with the names
+func LookupPasses(names []string) ([]Pass, error) {
+	passes := make([]Pass, 0, len(names))
+	for _, i := range names {
+		found := false
+		for _, j := range Passes {
+			if j.Name == i {
+				passes = append(passes, j)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPass, i)
+		}
+	}
+	return passes, nil
+}
+
+// AllLive returns every register as live
+func AllLive() [NumRegs]bool {
+	var live [NumRegs]bool
+	for n := range live {
+		live[n] = true
+	}
+	return live
+}
+
+// Optimize runs the passes in order until the program stops changing, and
+// returns the stats of every pass run
+func Optimize(p Program, passes []Pass, live [NumRegs]bool) (Program, []PassStats) {
+	var stats []PassStats
+	for {
+		before := p.String()
+		for _, i := range passes {
+			n := len(p)
+			p = i.Run(p, live)
+			stats = append(stats, PassStats{
+				Name:   i.Name,
+				Before: n,
+				After:  len(p),
+			})
+		}
+		if p.String() == before {
+			return p, stats
+		}
+	}
+}
+
+// ZeroMul replaces mul r 0 with set r 0
+func ZeroMul(p Program, live [NumRegs]bool) Program {
+	out := make(Program, 0, len(p))
+	for _, i := range p {
+		if i.Op == OpMul && i.Arg[1] == Imm(0) {
+			i = Instr{Op: OpSet, Arg: [2]Arg{i.Arg[0], Imm(0)}}
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// PropagateConstants tracks the registers with values known for every input,
+// starting from 0. It substitutes known values into args, replaces
+// instructions of known args with set, and removes instructions which leave
+// their destination unchanged, such as adding 0 or setting a register to its
+// known value.
+func PropagateConstants(p Program, live [NumRegs]bool) Program {
+	var known [NumRegs]bool
+	var vals [NumRegs]int
+	for n := range known {
+		known[n] = true
+	}
+	setKnown := func(r, v int) {
+		known[r] = true
+		vals[r] = v
+	}
+	out := make(Program, 0, len(p))
+	for _, i := range p {
+		dst := i.Arg[0].Val
+		if i.Op == OpInp {
+			known[dst] = false
+			out = append(out, i)
+			continue
+		}
+		b := i.Arg[1]
+		if !b.Imm && known[b.Val] {
+			b = Imm(vals[b.Val])
+		}
+		if known[dst] {
+			if !b.Imm {
+				// a known destination may become a copy of the arg
+				switch {
+				case i.Op == OpSet, i.Op == OpAdd && vals[dst] == 0, i.Op == OpMul && vals[dst] == 1:
+					out = append(out, Instr{Op: OpSet, Arg: [2]Arg{Reg(dst), b}})
+					known[dst] = false
+					continue
+				case i.Op == OpMul && vals[dst] == 0:
+					continue
+				}
+			} else if k, ok := eval(i.Op, vals[dst], b.Val); ok {
+				if k != vals[dst] {
+					out = append(out, Instr{Op: OpSet, Arg: [2]Arg{Reg(dst), Imm(k)}})
+					setKnown(dst, k)
+				}
+				continue
+			}
+		}
+		switch {
+		case i.Op == OpSet && b.Imm:
+			setKnown(dst, b.Val)
+		case i.Op == OpMul && b == Imm(0):
+			out = append(out, Instr{Op: OpSet, Arg: [2]Arg{Reg(dst), Imm(0)}})
+			setKnown(dst, 0)
+			continue
+		case (i.Op == OpAdd && b == Imm(0)) || ((i.Op == OpMul || i.Op == OpDiv) && b == Imm(1)):
+			continue
+		case i.Op == OpSet && b == Reg(dst):
+			continue
+		default:
+			known[dst] = false
+		}
+		out = append(out, Instr{Op: i.Op, Arg: [2]Arg{i.Arg[0], b}})
+	}
+	return out
+}
+
+// FuseNeq replaces eql r a followed by eql r 0 with neq r a, and neq r a
+// followed by eql r 0 with eql r a
+func FuseNeq(p Program, live [NumRegs]bool) Program {
+	out := make(Program, 0, len(p))
+	for n := 0; n < len(p); n++ {
+		i := p[n]
+		if (i.Op == OpEql || i.Op == OpNeq) && n+1 < len(p) {
+			next := p[n+1]
+			if next.Op == OpEql && next.Arg[0] == i.Arg[0] && next.Arg[1] == Imm(0) {
+				op := OpNeq
+				if i.Op == OpNeq {
+					op = OpEql
+				}
+				out = append(out, Instr{Op: op, Arg: i.Arg})
+				n++
+				continue
+			}
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+// EliminateDeadStores removes instructions which write a register that is not
+// read before it is written again, or is not live at the end. Inp is kept,
+// since removing it would change the inputs read after.
+func EliminateDeadStores(p Program, live [NumRegs]bool) Program {
+	keep := make([]bool, len(p))
+	for n := len(p) - 1; n >= 0; n-- {
+		i := p[n]
+		dst := i.Arg[0].Val
+		if i.Op == OpInp {
+			keep[n] = true
+			live[dst] = false
+			continue
+		}
+		if !live[dst] {
+			continue
+		}
+		keep[n] = true
+		if i.Op == OpSet {
+			live[dst] = false
+		}
+		if !i.Arg[1].Imm {
+			live[i.Arg[1].Val] = true
+		}
+	}
+	out := make(Program, 0, len(p))
+	for n, i := range p {
+		if keep[n] {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// Equivalent runs both programs on each of the inputs, and returns an error
+// for the first input which leaves different values in the live registers.
+// Inputs which crash the original program are skipped.
+func Equivalent(orig, opt Program, live [NumRegs]bool, stdins [][]int) error {
+	a, b := NewMachine(nil), NewMachine(nil)
+	for _, stdin := range stdins {
+		a.Reset(stdin)
+		if err := a.Run(orig); err != nil {
+			continue
+		}
+		b.Reset(stdin)
+		if err := b.Run(opt); err != nil {
+			return fmt.Errorf("%w: %v crashes the optimized program: %v", ErrNotEquivalent, stdin, err)
+		}
+		for r, ok := range live {
+			if ok && a.Reg(r) != b.Reg(r) {
+				return fmt.Errorf("%w: %v leaves %s as %d, not %d", ErrNotEquivalent, stdin, RegName(r), b.Reg(r), a.Reg(r))
+			}
+		}
+	}
+	return nil
+}
+
+// ParseLive parses registers named by a string such as "xz"
+func ParseLive(s string) ([NumRegs]bool, error) {
+	var live [NumRegs]bool
+	for _, i := range strings.Split(s, "") {
+		r, ok := parseReg(i)
+		if !ok {
+			return live, fmt.Errorf("%w: %s", ErrInvalidReg, i)
+		}
+		live[r] = true
+	}
+	return live, nil
+}
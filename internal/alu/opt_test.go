@@ -0,0 +1,98 @@
+package alu
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestPasses(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		pass func(p Program, live [NumRegs]bool) Program
+		src  string
+		want string
+	}{
+		{"zero", ZeroMul, "inp x\nmul x 0\nmul y 2\n", "inp x\nset x 0\nmul y 2\n"},
+		{"copy", PropagateConstants, "inp z\nmul x 0\nadd x z\n", "inp z\nset x z\n"},
+		{"fold", PropagateConstants, "add x 3\nmul x 4\ninp w\nadd y x\neql y 12\n", "set x 3\nset x 12\ninp w\nset y 12\nset y 1\n"},
+		{"identity", PropagateConstants, "inp w\nadd w 0\nmul w 1\ndiv w 1\nadd w x\n", "inp w\n"},
+		{"crash kept", PropagateConstants, "div x 0\n", "div x 0\n"},
+		{"neq", FuseNeq, "inp w\ninp x\neql x w\neql x 0\n", "inp w\ninp x\nneq x w\n"},
+		{"eql", FuseNeq, "inp w\nneq x w\neql x 0\neql y 0\n", "inp w\neql x w\neql y 0\n"},
+		{"dse", EliminateDeadStores, "inp w\nadd x w\nset x 3\nadd y x\ninp x\n", "inp w\nset x 3\nadd y x\ninp x\n"},
+	} {
+		prog, err := Parse(strings.NewReader(tc.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := tc.pass(prog, AllLive()).String(); got != tc.want {
+			t.Errorf("%s: want\n%s\ngot\n%s", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	rng := rand.New(rand.NewSource(22))
+	for n := 0; n < 300; n++ {
+		prog := randomProgram(rng, 40)
+		var stdins [][]int
+		for k := 0; k < 30; k++ {
+			stdins = append(stdins, randomInputs(rng, prog.Inputs()))
+		}
+		for _, live := range [][NumRegs]bool{AllLive(), {false, false, false, true}} {
+			for _, pass := range Passes {
+				if err := Equivalent(prog, pass.Run(prog, live), live, stdins); err != nil {
+					t.Fatalf("%s: %v\n%s", pass.Name, err, prog)
+				}
+			}
+			opt, stats := Optimize(prog, Passes, live)
+			if err := Equivalent(prog, opt, live, stdins); err != nil {
+				t.Fatalf("%v\n%s\noptimized to\n%s", err, prog, opt)
+			}
+			if len(stats) == 0 || stats[0].Before != len(prog) || stats[len(stats)-1].After != len(opt) {
+				t.Fatalf("stats %v do not span %d to %d instructions", stats, len(prog), len(opt))
+			}
+		}
+	}
+}
+
+func TestOptimizeMonad(t *testing.T) {
+	prog, err := ParseFile("../../day24/input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	opt, _ := Optimize(prog, Passes, AllLive())
+	if len(opt) >= len(prog) {
+		t.Errorf("want fewer than %d instructions, got %d", len(prog), len(opt))
+	}
+	if strings.Contains(opt.String(), "mul x 0") || strings.Contains(opt.String(), "eql x 0") {
+		t.Errorf("redundant instructions remain:\n%s", opt)
+	}
+	rng := rand.New(rand.NewSource(24))
+	var stdins [][]int
+	for k := 0; k < 200; k++ {
+		stdins = append(stdins, randomInputs(rng, prog.Inputs()))
+	}
+	if err := Equivalent(prog, opt, AllLive(), stdins); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEquivalentMismatch(t *testing.T) {
+	a, err := Parse(strings.NewReader("inp w\nadd z w\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := Parse(strings.NewReader("inp w\nadd z 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Equivalent(a, b, AllLive(), [][]int{{1}, {2}}); !errors.Is(err, ErrNotEquivalent) {
+		t.Errorf("want error %v, got %v", ErrNotEquivalent, err)
+	}
+	if _, err := LookupPasses([]string{"zero", "unroll"}); !errors.Is(err, ErrInvalidPass) {
+		t.Errorf("want error %v, got %v", ErrInvalidPass, err)
+	}
+}
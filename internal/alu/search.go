@@ -0,0 +1,324 @@
+package alu
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/xorkevin/advent2021/internal/memo"
+)
+
+var (
+	ErrNoSolution = errors.New("No inputs leave z as 0")
+)
+
+type (
+	// SearchOptions configure a search
+	SearchOptions struct {
+		// Largest is whether to find the largest inputs rather than the
+		// smallest, comparing the first input first
+		Largest bool
+		// Min and Max bound every input
+		Min int
+		Max int
+		// Workers is the number of goroutines searching at once, where less
+		// than 1 is the number of CPUs
+		Workers int
+		// Prune returns whether no inputs from the 0-indexed block on can leave
+		// z as 0, given the registers at the start of the block. It may be
+		// nil.
+		Prune func(block int, regs [NumRegs]int) bool
+	}
+
+	// SearchStats counts the work done by a search
+	SearchStats struct {
+		// Blocks is the number of blocks run
+		Blocks int64
+		// Dead is the number of block states memoized as leaving no inputs
+		Dead int
+	}
+
+	// searchState is the start of a block, where registers which are not live
+	// are zeroed
+	searchState struct {
+		block int
+		regs  [NumRegs]int
+	}
+
+	searcher struct {
+		ctx    context.Context
+		opts   SearchOptions
+		init   Program
+		blocks []Program
+		live   [][NumRegs]bool
+		order  []int
+		// dead holds the states from which no inputs leave z as 0
+		dead *memo.Memo[searchState, struct{}]
+		// best is the index of the earliest task to find inputs
+		best int64
+		runs int64
+	}
+)
+
+// errAbandoned stops a task once an earlier task has found inputs
+var errAbandoned = errors.New("Abandoned")
+
+// splitBlocks returns the instructions before the first inp, and each block of
+// instructions from an inp up to the next
+func splitBlocks(p Program) (Program, []Program) {
+	start := len(p)
+	for n, i := range p {
+		if i.Op == OpInp {
+			start = n
+			break
+		}
+	}
+	var blocks []Program
+	for n := len(p) - 1; n >= start; n-- {
+		if p[n].Op == OpInp {
+			blocks = append(blocks, p[n:])
+			p = p[:n]
+		}
+	}
+	for i, j := 0, len(blocks)-1; i < j; i, j = i+1, j-1 {
+		blocks[i], blocks[j] = blocks[j], blocks[i]
+	}
+	return p[:start], blocks
+}
+
+// liveIn returns the registers at the start of each block which may be read
+// before they are written, given that only z is read at the end
+func liveIn(blocks []Program) [][NumRegs]bool {
+	live := make([][NumRegs]bool, len(blocks))
+	var regs [NumRegs]bool
+	regs[RegZ] = true
+	for n := len(blocks) - 1; n >= 0; n-- {
+		b := blocks[n]
+		for k := len(b) - 1; k >= 0; k-- {
+			i := b[k]
+			dst := i.Arg[0].Val
+			switch {
+			case i.Op == OpInp, i.Op == OpMul && i.Arg[1] == Imm(0):
+				regs[dst] = false
+				continue
+			case i.Op == OpSet:
+				regs[dst] = false
+			default:
+				// args are read even if the result is not, since they decide
+				// whether div and mod crash
+				regs[dst] = true
+			}
+			if !i.Arg[1].Imm {
+				regs[i.Arg[1].Val] = true
+			}
+		}
+		live[n] = regs
+	}
+	return live
+}
+
+// Search finds the largest or smallest inputs which leave z as 0 by trying
+// every input of each block in order, and memoizing the states at the start of
+// a block from which no inputs succeed. A state is the block and its live
+// registers, so a block which reads only z is memoized on z alone. An input
+// which crashes the program is treated as failing. The first two inputs are
+// split among the workers, and the search stops when ctx is done.
+func Search(ctx context.Context, p Program, opts SearchOptions) ([]int, SearchStats, error) {
+	init, blocks := splitBlocks(p)
+	if len(blocks) == 0 {
+		return nil, SearchStats{}, ErrNoSolution
+	}
+	s := &searcher{
+		opts:   opts,
+		init:   init,
+		blocks: blocks,
+		live:   liveIn(blocks),
+		order:  inputOrder(opts),
+		dead:   memo.New[searchState, struct{}](0),
+	}
+	split := 2
+	if split > len(blocks) {
+		split = len(blocks)
+	}
+	tasks := [][]int{nil}
+	for n := 0; n < split; n++ {
+		next := make([][]int, 0, len(tasks)*len(s.order))
+		for _, i := range tasks {
+			for _, d := range s.order {
+				next = append(next, append(append([]int{}, i...), d))
+			}
+		}
+		tasks = next
+	}
+
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.ctx = ctx
+	s.best = int64(len(tasks))
+
+	results := make([][]int, len(tasks))
+	var searchErr error
+	var mu sync.Mutex
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			m := NewMachine(nil)
+			for n := range queue {
+				if int64(n) > atomic.LoadInt64(&s.best) {
+					continue
+				}
+				stdin, err := s.task(m, n, tasks[n])
+				if err != nil {
+					if errors.Is(err, errAbandoned) {
+						continue
+					}
+					mu.Lock()
+					if searchErr == nil {
+						searchErr = err
+					}
+					mu.Unlock()
+					cancel()
+					continue
+				}
+				if stdin == nil {
+					continue
+				}
+				results[n] = stdin
+				for {
+					k := atomic.LoadInt64(&s.best)
+					if int64(n) >= k || atomic.CompareAndSwapInt64(&s.best, k, int64(n)) {
+						break
+					}
+				}
+			}
+		}()
+	}
+	for n := range tasks {
+		if ctx.Err() != nil {
+			break
+		}
+		queue <- n
+	}
+	close(queue)
+	wg.Wait()
+
+	stats := SearchStats{
+		Blocks: atomic.LoadInt64(&s.runs),
+		Dead:   s.dead.Len(),
+	}
+	if searchErr != nil {
+		return nil, stats, searchErr
+	}
+	// ctx may be done before any task has started
+	if err := ctx.Err(); err != nil {
+		return nil, stats, err
+	}
+	if s.best < int64(len(tasks)) {
+		return results[s.best], stats, nil
+	}
+	return nil, stats, ErrNoSolution
+}
+
+// inputOrder returns the inputs to try in order
+func inputOrder(opts SearchOptions) []int {
+	var digits []int
+	for d := opts.Min; d <= opts.Max; d++ {
+		digits = append(digits, d)
+	}
+	if opts.Largest {
+		for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+			digits[i], digits[j] = digits[j], digits[i]
+		}
+	}
+	return digits
+}
+
+// runBlock runs a block on an input from the registers, returning false if it
+// crashes
+func (s *searcher) runBlock(m *Machine, block int, regs [NumRegs]int, d int) ([NumRegs]int, bool) {
+	atomic.AddInt64(&s.runs, 1)
+	m.reg = regs
+	m.stdin = []int{d}
+	m.inp = 0
+	if err := m.Run(s.blocks[block]); err != nil {
+		return regs, false
+	}
+	return m.reg, true
+}
+
+// task searches from the inputs of the nth task, returning nil if no inputs
+// succeed
+func (s *searcher) task(m *Machine, n int, prefix []int) ([]int, error) {
+	m.Reset(nil)
+	if err := m.Run(s.init); err != nil {
+		return nil, nil
+	}
+	regs := m.Regs()
+	for k, d := range prefix {
+		if s.opts.Prune != nil && s.opts.Prune(k, regs) {
+			return nil, nil
+		}
+		var ok bool
+		if regs, ok = s.runBlock(m, k, regs, d); !ok {
+			return nil, nil
+		}
+	}
+	stdin := make([]int, len(s.blocks))
+	copy(stdin, prefix)
+	found, err := s.dfs(m, n, len(prefix), regs, stdin)
+	if err != nil || !found {
+		return nil, err
+	}
+	return stdin, nil
+}
+
+// dfs searches the inputs from a block on, filling in stdin, and returns
+// whether any leave z as 0
+func (s *searcher) dfs(m *Machine, task int, block int, regs [NumRegs]int, stdin []int) (bool, error) {
+	if block == len(s.blocks) {
+		return regs[RegZ] == 0, nil
+	}
+	if err := s.ctx.Err(); err != nil {
+		return false, err
+	}
+	if int64(task) > atomic.LoadInt64(&s.best) {
+		return false, errAbandoned
+	}
+	key := searchState{block: block}
+	for r, ok := range s.live[block] {
+		if ok {
+			key.regs[r] = regs[r]
+		}
+	}
+	if _, ok := s.dead.Get(key); ok {
+		return false, nil
+	}
+	if s.opts.Prune != nil && s.opts.Prune(block, regs) {
+		return false, nil
+	}
+	for _, d := range s.order {
+		next, ok := s.runBlock(m, block, regs, d)
+		if !ok {
+			continue
+		}
+		stdin[block] = d
+		found, err := s.dfs(m, task, block+1, next, stdin)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	s.dead.Set(key, struct{}{})
+	return false, nil
+}
@@ -0,0 +1,109 @@
+package alu
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// bruteSearch returns the smallest and largest inputs from 1 to 3 which leave z
+// as 0, or nil if there are none
+func bruteSearch(prog Program) ([]int, []int) {
+	m := NewMachine(nil)
+	var smallest, largest []int
+	stdin := make([]int, prog.Inputs())
+	var try func(n int)
+	try = func(n int) {
+		if n == len(stdin) {
+			m.Reset(stdin)
+			if err := m.Run(prog); err != nil || m.Reg(RegZ) != 0 {
+				return
+			}
+			if smallest == nil {
+				smallest = append([]int{}, stdin...)
+			}
+			largest = append([]int{}, stdin...)
+			return
+		}
+		for d := 1; d <= 3; d++ {
+			stdin[n] = d
+			try(n + 1)
+		}
+	}
+	try(0)
+	return smallest, largest
+}
+
+func TestSearch(t *testing.T) {
+	rng := rand.New(rand.NewSource(24))
+	found := 0
+	for n := 0; n < 300; n++ {
+		prog := randomProgram(rng, 30)
+		if k := prog.Inputs(); k == 0 || k > 5 {
+			continue
+		}
+		wantSmallest, wantLargest := bruteSearch(prog)
+		for _, largest := range []bool{false, true} {
+			want := wantSmallest
+			if largest {
+				want = wantLargest
+			}
+			for _, workers := range []int{1, 4} {
+				got, _, err := Search(context.Background(), prog, SearchOptions{
+					Largest: largest,
+					Min:     1,
+					Max:     3,
+					Workers: workers,
+				})
+				if want == nil {
+					if !errors.Is(err, ErrNoSolution) {
+						t.Fatalf("want error %v, got %v and %v\n%s", ErrNoSolution, got, err, prog)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("largest %t with %d workers: want %v, got %v\n%s", largest, workers, want, got, prog)
+				}
+			}
+		}
+		if wantSmallest != nil {
+			found++
+		}
+	}
+	if found == 0 {
+		t.Error("want some programs with inputs which leave z as 0")
+	}
+}
+
+func TestSearchLive(t *testing.T) {
+	prog, err := ParseFile("../../day24/input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	init, blocks := splitBlocks(prog)
+	if len(init) != 0 || len(blocks) != 14 {
+		t.Fatalf("want 14 blocks and no instructions before, got %d and %d", len(blocks), len(init))
+	}
+	for n, i := range liveIn(blocks) {
+		if want := [NumRegs]bool{RegZ: true}; i != want {
+			t.Errorf("block %d: want only z live, got %v", n+1, i)
+		}
+	}
+}
+
+func TestSearchCancel(t *testing.T) {
+	prog, err := ParseFile("../../day24/input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := Search(ctx, prog, SearchOptions{Min: 1, Max: 9}); !errors.Is(err, context.Canceled) {
+		t.Errorf("want error %v, got %v", context.Canceled, err)
+	}
+}
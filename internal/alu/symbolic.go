@@ -0,0 +1,568 @@
+package alu
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type (
+	// ExprKind is the kind of node of an expression
+	ExprKind int
+
+	// Expr is an expression of the inputs of a program, or of the registers
+	// at the start of a block, with the range of values it may take. Exprs are
+	// immutable and may share subexpressions.
+	Expr struct {
+		Kind ExprKind
+		// Val is the value of a constant, the 0-indexed input, or the index of
+		// the register
+		Val  int
+		Args [2]*Expr
+		// Min and Max bound the value of the expression when no instruction
+		// crashes
+		Min int
+		Max int
+	}
+)
+
+const (
+	ExprConst ExprKind = iota
+	ExprInput
+	ExprReg
+	ExprAdd
+	ExprMul
+	ExprDiv
+	ExprMod
+	ExprEql
+	ExprNeq
+)
+
+// Const returns a constant
+func Const(v int) *Expr {
+	return &Expr{Kind: ExprConst, Val: v, Min: v, Max: v}
+}
+
+// Input returns the 0-indexed input which is at least min and at most max
+func Input(n, min, max int) *Expr {
+	return &Expr{Kind: ExprInput, Val: n, Min: min, Max: max}
+}
+
+// RegVar returns the value of a register at the start of a block, which is at
+// least min and at most max
+func RegVar(r, min, max int) *Expr {
+	return &Expr{Kind: ExprReg, Val: r, Min: min, Max: max}
+}
+
+// IsConst returns whether the expression has a single value
+func (e *Expr) IsConst() bool {
+	return e.Min == e.Max
+}
+
+func (e *Expr) isConst(v int) bool {
+	return e.Kind == ExprConst && e.Val == v
+}
+
+// Equal returns whether two expressions are the same
+func (e *Expr) Equal(o *Expr) bool {
+	if e == o {
+		return true
+	}
+	if e.Kind != o.Kind || e.Val != o.Val {
+		return false
+	}
+	switch e.Kind {
+	case ExprConst, ExprInput, ExprReg:
+		return true
+	}
+	return e.Args[0].Equal(o.Args[0]) && e.Args[1].Equal(o.Args[1])
+}
+
+func satAdd(a, b int) int {
+	c := a + b
+	if a > 0 && b > 0 && c < 0 {
+		return math.MaxInt
+	}
+	if a < 0 && b < 0 && c >= 0 {
+		return math.MinInt
+	}
+	return c
+}
+
+func satMul(a, b int) int {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		if (a < 0) == (b < 0) {
+			return math.MaxInt
+		}
+		return math.MinInt
+	}
+	return c
+}
+
+func minMax(vals ...int) (int, int) {
+	lo, hi := vals[0], vals[0]
+	for _, i := range vals[1:] {
+		if i < lo {
+			lo = i
+		}
+		if i > hi {
+			hi = i
+		}
+	}
+	return lo, hi
+}
+
+func binary(kind ExprKind, a, b *Expr, min, max int) *Expr {
+	if min == max {
+		return Const(min)
+	}
+	return &Expr{Kind: kind, Args: [2]*Expr{a, b}, Min: min, Max: max}
+}
+
+// isStackPush returns the value pushed and popped by a*k + b, where a is not
+// negative and b is at least 0 and less than k
+func isStackPush(e *Expr, k int) (*Expr, *Expr, bool) {
+	if k <= 0 {
+		return nil, nil, false
+	}
+	var top, rest *Expr
+	switch {
+	case e.Kind == ExprMul:
+		top, rest = Const(0), e
+	case e.Kind == ExprAdd && e.Args[0].Kind == ExprMul:
+		top, rest = e.Args[1], e.Args[0]
+	default:
+		return nil, nil, false
+	}
+	if !rest.Args[1].isConst(k) || rest.Args[0].Min < 0 || top.Min < 0 || top.Max >= k {
+		return nil, nil, false
+	}
+	return rest.Args[0], top, true
+}
+
+// Add returns a simplified a + b
+func Add(a, b *Expr) *Expr {
+	if a.Kind == ExprConst && b.Kind == ExprConst {
+		return Const(a.Val + b.Val)
+	}
+	if a.Kind == ExprConst {
+		a, b = b, a
+	}
+	if b.isConst(0) {
+		return a
+	}
+	if b.Kind == ExprConst && a.Kind == ExprAdd && a.Args[1].Kind == ExprConst {
+		return Add(a.Args[0], Const(a.Args[1].Val+b.Val))
+	}
+	return binary(ExprAdd, a, b, satAdd(a.Min, b.Min), satAdd(a.Max, b.Max))
+}
+
+// Mul returns a simplified a * b
+func Mul(a, b *Expr) *Expr {
+	if a.Kind == ExprConst && b.Kind == ExprConst {
+		return Const(a.Val * b.Val)
+	}
+	if a.Kind == ExprConst {
+		a, b = b, a
+	}
+	if b.isConst(0) {
+		return b
+	}
+	if b.isConst(1) {
+		return a
+	}
+	min, max := minMax(satMul(a.Min, b.Min), satMul(a.Min, b.Max), satMul(a.Max, b.Min), satMul(a.Max, b.Max))
+	return binary(ExprMul, a, b, min, max)
+}
+
+// Div returns a simplified a / b, truncated toward zero
+func Div(a, b *Expr) *Expr {
+	if b.isConst(1) {
+		return a
+	}
+	if a.Kind == ExprConst && b.Kind == ExprConst && b.Val != 0 {
+		return Const(a.Val / b.Val)
+	}
+	if b.Kind == ExprConst && b.Val > 0 {
+		if a.Min > -b.Val && a.Max < b.Val {
+			return Const(0)
+		}
+		if rest, _, ok := isStackPush(a, b.Val); ok {
+			return rest
+		}
+	}
+	var min, max int
+	switch {
+	case b.Min > 0 || b.Max < 0:
+		min, max = minMax(a.Min/b.Min, a.Min/b.Max, a.Max/b.Min, a.Max/b.Max)
+	default:
+		// dividing by a value near 0 keeps the magnitude of a at most
+		k := a.Max
+		if -a.Min > k {
+			k = -a.Min
+		}
+		if a.Min == math.MinInt {
+			k = math.MaxInt
+		}
+		min, max = -k, k
+	}
+	return binary(ExprDiv, a, b, min, max)
+}
+
+// Mod returns a simplified a % b, where a is not negative and b is positive
+func Mod(a, b *Expr) *Expr {
+	if a.Kind == ExprConst && b.Kind == ExprConst && a.Val >= 0 && b.Val > 0 {
+		return Const(a.Val % b.Val)
+	}
+	if b.Kind == ExprConst && b.Val > 0 {
+		if a.Min >= 0 && a.Max < b.Val {
+			return a
+		}
+		if _, top, ok := isStackPush(a, b.Val); ok {
+			return top
+		}
+	}
+	max := b.Max - 1
+	if a.Max < max {
+		max = a.Max
+	}
+	if max < 0 {
+		max = 0
+	}
+	return binary(ExprMod, a, b, 0, max)
+}
+
+// Eql returns a simplified 1 if a equals b, and 0 otherwise
+func Eql(a, b *Expr) *Expr {
+	if a.Kind == ExprConst {
+		a, b = b, a
+	}
+	if a.Max < b.Min || b.Max < a.Min {
+		return Const(0)
+	}
+	if a.Equal(b) {
+		return Const(1)
+	}
+	if a.Kind == ExprEql || a.Kind == ExprNeq {
+		switch {
+		case b.isConst(1):
+			return a
+		case b.isConst(0):
+			kind := ExprNeq
+			if a.Kind == ExprNeq {
+				kind = ExprEql
+			}
+			return &Expr{Kind: kind, Args: a.Args, Min: 0, Max: 1}
+		}
+	}
+	return binary(ExprEql, a, b, 0, 1)
+}
+
+// Apply returns the simplified result of an op
+func Apply(op Op, a, b *Expr) *Expr {
+	switch op {
+	case OpAdd:
+		return Add(a, b)
+	case OpMul:
+		return Mul(a, b)
+	case OpDiv:
+		return Div(a, b)
+	case OpMod:
+		return Mod(a, b)
+	case OpEql:
+		return Eql(a, b)
+	case OpSet:
+		return b
+	case OpNeq:
+		return Eql(Eql(a, b), Const(0))
+	default:
+		return nil
+	}
+}
+
+var exprSymbols = [...]string{
+	ExprAdd: "+",
+	ExprMul: "*",
+	ExprDiv: "/",
+	ExprMod: "%",
+	ExprEql: "==",
+	ExprNeq: "!=",
+}
+
+// prec returns the precedence of the expression as in Go, where eql and neq
+// are always parenthesized since they are ints
+func (e *Expr) prec() int {
+	switch e.Kind {
+	case ExprAdd:
+		return 1
+	case ExprMul, ExprDiv, ExprMod:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func (e *Expr) writeTo(b *strings.Builder, prec int) {
+	p := e.prec()
+	if p < prec {
+		b.WriteByte('(')
+		defer b.WriteByte(')')
+	}
+	switch e.Kind {
+	case ExprConst:
+		b.WriteString(strconv.Itoa(e.Val))
+	case ExprInput:
+		b.WriteString("d")
+		b.WriteString(strconv.Itoa(e.Val + 1))
+	case ExprReg:
+		b.WriteString(RegName(e.Val))
+	case ExprEql, ExprNeq:
+		b.WriteByte('(')
+		e.Args[0].writeTo(b, 0)
+		b.WriteString(" " + exprSymbols[e.Kind] + " ")
+		e.Args[1].writeTo(b, 0)
+		b.WriteByte(')')
+	default:
+		e.Args[0].writeTo(b, p)
+		rhs := e.Args[1]
+		if e.Kind == ExprAdd && rhs.Kind == ExprConst && rhs.Val < 0 && rhs.Val != math.MinInt {
+			b.WriteString(" - ")
+			b.WriteString(strconv.Itoa(-rhs.Val))
+			return
+		}
+		b.WriteString(" " + exprSymbols[e.Kind] + " ")
+		// only a sum of sums and a product of products are associative
+		if (e.Kind == ExprAdd || e.Kind == ExprMul) && rhs.Kind == e.Kind {
+			rhs.writeTo(b, p)
+		} else {
+			rhs.writeTo(b, p+1)
+		}
+	}
+}
+
+func (e *Expr) String() string {
+	b := strings.Builder{}
+	e.writeTo(&b, 0)
+	return b.String()
+}
+
+// Range returns the range of the expression as [min, max]
+func (e *Expr) Range() string {
+	return "[" + strconv.Itoa(e.Min) + ", " + strconv.Itoa(e.Max) + "]"
+}
+
+type (
+	// Symbolic runs a program on expressions rather than values
+	Symbolic struct {
+		regs     [NumRegs]*Expr
+		inp      int
+		inputMin int
+		inputMax int
+	}
+
+	// EqlFact is an eql or neq instruction whose result is the same for every
+	// input
+	EqlFact struct {
+		// Index is the 0-indexed position of the instruction in its program
+		Index int
+		Instr Instr
+		Value int
+	}
+
+	// BlockFormula is the value of each register at the end of a block of a
+	// program starting with an inp, in terms of the registers at the start of
+	// the block and its input
+	BlockFormula struct {
+		// Start is the index of the first instruction of the block, and End is
+		// the index after its last
+		Start int
+		End   int
+		// Input is the 0-indexed input read by the block, or -1 for the
+		// instructions before the first inp
+		Input int
+		// In is the range of each register at the start of the block
+		In [NumRegs]*Expr
+		// Out is the value of each register at the end of the block
+		Out [NumRegs]*Expr
+	}
+
+	// Analysis is the symbolic execution of a program
+	Analysis struct {
+		// Regs is the final value of each register in terms of the inputs
+		Regs [NumRegs]*Expr
+		Eqls []EqlFact
+		// Blocks holds the formula of each block ending before an inp
+		Blocks []BlockFormula
+	}
+)
+
+// NewSymbolic returns a symbolic machine whose registers start at regs, and
+// whose inputs are at least inputMin and at most inputMax
+func NewSymbolic(regs [NumRegs]*Expr, inputMin, inputMax int) *Symbolic {
+	return &Symbolic{
+		regs:     regs,
+		inputMin: inputMin,
+		inputMax: inputMax,
+	}
+}
+
+// Regs returns the expression of every register
+func (s *Symbolic) Regs() [NumRegs]*Expr {
+	return s.regs
+}
+
+// SkipInputs sets the index of the next input read
+func (s *Symbolic) SkipInputs(n int) {
+	s.inp = n
+}
+
+// Exec runs an instruction, and returns the new value of its destination
+func (s *Symbolic) Exec(instr Instr) *Expr {
+	dst := instr.Arg[0].Val
+	if instr.Op == OpInp {
+		s.regs[dst] = Input(s.inp, s.inputMin, s.inputMax)
+		s.inp++
+		return s.regs[dst]
+	}
+	b := instr.Arg[1]
+	arg := Const(b.Val)
+	if !b.Imm {
+		arg = s.regs[b.Val]
+	}
+	s.regs[dst] = Apply(instr.Op, s.regs[dst], arg)
+	return s.regs[dst]
+}
+
+func zeroRegs() [NumRegs]*Expr {
+	var regs [NumRegs]*Expr
+	for n := range regs {
+		regs[n] = Const(0)
+	}
+	return regs
+}
+
+// Analyze symbolically runs a program whose inputs are at least inputMin and
+// at most inputMax, finding the eql instructions with constant results and the
+// formula of each block
+func Analyze(p Program, inputMin, inputMax int) *Analysis {
+	a := &Analysis{}
+	s := NewSymbolic(zeroRegs(), inputMin, inputMax)
+	var starts []int
+	var in [][NumRegs]*Expr
+	for n, i := range p {
+		if n == 0 || i.Op == OpInp {
+			starts = append(starts, n)
+			in = append(in, s.Regs())
+		}
+		k := s.Exec(i)
+		if (i.Op == OpEql || i.Op == OpNeq) && k.Kind == ExprConst {
+			a.Eqls = append(a.Eqls, EqlFact{
+				Index: n,
+				Instr: i,
+				Value: k.Val,
+			})
+		}
+	}
+	a.Regs = s.Regs()
+
+	inputs := 0
+	for n, start := range starts {
+		end := len(p)
+		if n+1 < len(starts) {
+			end = starts[n+1]
+		}
+		f := BlockFormula{
+			Start: start,
+			End:   end,
+			Input: -1,
+		}
+		var regs [NumRegs]*Expr
+		for r, i := range in[n] {
+			regs[r] = i
+			if i.Kind != ExprConst {
+				regs[r] = RegVar(r, i.Min, i.Max)
+			}
+			f.In[r] = regs[r]
+		}
+		bs := NewSymbolic(regs, inputMin, inputMax)
+		bs.SkipInputs(inputs)
+		if p[start].Op == OpInp {
+			f.Input = inputs
+			inputs++
+		}
+		for _, i := range p[start:end] {
+			bs.Exec(i)
+		}
+		f.Out = bs.Regs()
+		a.Blocks = append(a.Blocks, f)
+	}
+	return a
+}
+
+// uses returns whether the expression depends on the register at the start of
+// its block
+func (e *Expr) uses(r int) bool {
+	switch e.Kind {
+	case ExprReg:
+		return e.Val == r
+	case ExprConst, ExprInput:
+		return false
+	}
+	return e.Args[0].uses(r) || e.Args[1].uses(r)
+}
+
+// reads returns whether any register at the end of the block depends on the
+// register at the start
+func (f BlockFormula) reads(r int) bool {
+	for _, i := range f.Out {
+		if i.uses(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteReport writes the constant eql results, and the formula of each register
+// written by each block with its range
+func (a *Analysis) WriteReport(w io.Writer) error {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "%d eql instructions are constant\n", len(a.Eqls))
+	for _, i := range a.Eqls {
+		fmt.Fprintf(b, "  %d: %s is always %d\n", i.Index+1, i.Instr, i.Value)
+	}
+	for _, f := range a.Blocks {
+		b.WriteByte('\n')
+		if f.Input < 0 {
+			fmt.Fprintf(b, "instructions %d to %d before any input\n", f.Start+1, f.End)
+		} else {
+			fmt.Fprintf(b, "d%d: instructions %d to %d\n", f.Input+1, f.Start+1, f.End)
+		}
+		for r, i := range f.In {
+			if i.Kind == ExprReg && f.reads(r) {
+				fmt.Fprintf(b, "  %s in %s\n", RegName(r), i.Range())
+			}
+		}
+		for r, i := range f.Out {
+			if i == f.In[r] || i.Equal(f.In[r]) {
+				continue
+			}
+			fmt.Fprintf(b, "  %s = %s", RegName(r), i)
+			if !i.IsConst() {
+				fmt.Fprintf(b, " in %s", i.Range())
+			}
+			b.WriteByte('\n')
+		}
+	}
+	b.WriteString("\nfinal ranges\n")
+	for r, i := range a.Regs {
+		fmt.Fprintf(b, "  %s in %s\n", RegName(r), i.Range())
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,142 @@
+package alu
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// evalExpr computes an expression on inputs and the registers at the start of
+// its block
+func evalExpr(e *Expr, stdin []int, regs [NumRegs]int) int {
+	switch e.Kind {
+	case ExprConst:
+		return e.Val
+	case ExprInput:
+		return stdin[e.Val]
+	case ExprReg:
+		return regs[e.Val]
+	case ExprNeq:
+		return 1 - evalExpr(&Expr{Kind: ExprEql, Args: e.Args}, stdin, regs)
+	}
+	a, b := evalExpr(e.Args[0], stdin, regs), evalExpr(e.Args[1], stdin, regs)
+	ops := map[ExprKind]Op{
+		ExprAdd: OpAdd,
+		ExprMul: OpMul,
+		ExprDiv: OpDiv,
+		ExprMod: OpMod,
+		ExprEql: OpEql,
+	}
+	k, _ := eval(ops[e.Kind], a, b)
+	return k
+}
+
+func checkExpr(t *testing.T, name string, e *Expr, want int, stdin []int, regs [NumRegs]int, prog Program) {
+	t.Helper()
+	if got := evalExpr(e, stdin, regs); got != want {
+		t.Fatalf("%s = %s on %v from %v: want %d, got %d\n%s", name, e, stdin, regs, want, got, prog)
+	}
+	if want < e.Min || want > e.Max {
+		t.Fatalf("%s = %s on %v from %v: %d out of range %s\n%s", name, e, stdin, regs, want, e.Range(), prog)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	rng := rand.New(rand.NewSource(21))
+	m := NewMachine(nil)
+	for n := 0; n < 300; n++ {
+		prog := randomProgram(rng, 40)
+		a := Analyze(prog, 1, 9)
+		for k := 0; k < 20; k++ {
+			stdin := randomInputs(rng, prog.Inputs())
+			m.Reset(stdin)
+			var starts [][NumRegs]int
+			crashed := false
+			for idx, i := range prog {
+				if idx == 0 || i.Op == OpInp {
+					starts = append(starts, m.Regs())
+				}
+				if err := m.Exec(i); err != nil {
+					crashed = true
+					break
+				}
+			}
+			if crashed {
+				continue
+			}
+			for r, i := range a.Regs {
+				checkExpr(t, RegName(r), i, m.Reg(r), stdin, [NumRegs]int{}, prog)
+			}
+			for _, i := range a.Eqls {
+				m.Reset(stdin)
+				if err := m.Run(prog[:i.Index+1]); err != nil {
+					t.Fatal(err)
+				}
+				if got := m.Reg(i.Instr.Arg[0].Val); got != i.Value {
+					t.Fatalf("instruction %d on %v: want constant %d, got %d\n%s", i.Index+1, stdin, i.Value, got, prog)
+				}
+			}
+			for b, f := range a.Blocks {
+				m.Reset(stdin)
+				if err := m.Run(prog[:f.End]); err != nil {
+					t.Fatal(err)
+				}
+				for r, i := range f.Out {
+					checkExpr(t, RegName(r), i, m.Reg(r), stdin, starts[b], prog)
+				}
+			}
+		}
+	}
+}
+
+func TestAnalyzeMonad(t *testing.T) {
+	prog, err := ParseFile("../../day24/input.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := Analyze(prog, 1, 9)
+	// each of the 7 blocks which push proves both of its eql instructions
+	if len(a.Eqls) != 14 {
+		t.Errorf("want 14 constant eqls, got %d", len(a.Eqls))
+	}
+	if len(a.Blocks) != 14 {
+		t.Fatalf("want 14 blocks, got %d", len(a.Blocks))
+	}
+	for n, want := range map[int]string{
+		0: "d1 + 2",
+		1: "z * 26 + d2 + 16",
+		4: "z / 26 * ((z % 26 - 8 != d5) * 25 + 1) + (d5 + 1) * (z % 26 - 8 != d5)",
+	} {
+		if got := a.Blocks[n].Out[RegZ].String(); got != want {
+			t.Errorf("block %d: want z = %s, got %s", n+1, want, got)
+		}
+	}
+	b := &strings.Builder{}
+	if err := a.WriteReport(b); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), "7: eql x w is always 0\n") {
+		t.Errorf("report is missing the first constant eql:\n%s", b)
+	}
+}
+
+func TestExprString(t *testing.T) {
+	d := Input(0, 1, 9)
+	z := RegVar(RegZ, 0, 100)
+	for _, tc := range []struct {
+		e    *Expr
+		want string
+	}{
+		{Add(Add(d, Const(3)), Const(-5)), "d1 - 2"},
+		{Div(z, Add(d, Const(1))), "z / (d1 + 1)"},
+		{Mul(z, Div(z, Const(3))), "z * (z / 3)"},
+		{Mod(Add(Mul(z, Const(26)), Add(d, Const(4))), Const(26)), "d1 + 4"},
+		{Div(Add(Mul(z, Const(26)), Add(d, Const(4))), Const(26)), "z"},
+		{Eql(Eql(z, d), Const(0)), "(z != d1)"},
+		{Eql(Add(d, Const(10)), d), "0"},
+	} {
+		if got := tc.e.String(); got != tc.want {
+			t.Errorf("want %s, got %s", tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package alu
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+)
+
+var (
+	ErrHalted = errors.New("Program halted")
+)
+
+type (
+	// Step is an instruction run by a debugger, and the registers after it
+	Step struct {
+		// Index is the 0-indexed position of the instruction in its program
+		Index int
+		Instr Instr
+		// Input is the 0-indexed input read by an inp, or -1
+		Input int
+		Regs  [NumRegs]int
+	}
+
+	// Debugger runs a program one instruction at a time, recording each step
+	// run
+	Debugger struct {
+		m        *Machine
+		prog     Program
+		pc       int
+		breaks   map[int]struct{}
+		breakInp bool
+		err      error
+		// Trace holds every step run
+		Trace []Step
+	}
+
+	// DigitSummary is the value of z before and after the instructions from an
+	// inp up to the next inp
+	DigitSummary struct {
+		// Input is the 0-indexed input read
+		Input int
+		// Index is the 0-indexed position of the inp
+		Index int
+		Value int
+		ZIn   int
+		ZOut  int
+	}
+)
+
+// NewDebugger returns a debugger stopped before the first instruction of the
+// program
+func NewDebugger(prog Program, stdin []int) *Debugger {
+	return &Debugger{
+		m:      NewMachine(stdin),
+		prog:   prog,
+		breaks: map[int]struct{}{},
+	}
+}
+
+// Break sets a breakpoint before the 0-indexed instruction
+func (d *Debugger) Break(index int) {
+	d.breaks[index] = struct{}{}
+}
+
+// Clear removes the breakpoint before the 0-indexed instruction
+func (d *Debugger) Clear(index int) {
+	delete(d.breaks, index)
+}
+
+// BreakOnInp sets whether to break before every inp
+func (d *Debugger) BreakOnInp(b bool) {
+	d.breakInp = b
+}
+
+// PC returns the index of the next instruction to run
+func (d *Debugger) PC() int {
+	return d.pc
+}
+
+// Regs returns the values of every register
+func (d *Debugger) Regs() [NumRegs]int {
+	return d.m.Regs()
+}
+
+// Done returns whether the program has halted, either by running every
+// instruction or by crashing
+func (d *Debugger) Done() bool {
+	return d.err != nil || d.pc >= len(d.prog)
+}
+
+// Err returns the ExecError which crashed the program, if any
+func (d *Debugger) Err() error {
+	return d.err
+}
+
+// atBreak returns whether the next instruction has a breakpoint
+func (d *Debugger) atBreak() bool {
+	if _, ok := d.breaks[d.pc]; ok {
+		return true
+	}
+	return d.breakInp && d.prog[d.pc].Op == OpInp
+}
+
+// Step runs the next instruction, returning ErrHalted if the program has
+// halted, or an ExecError if it crashes
+func (d *Debugger) Step() (Step, error) {
+	if d.err != nil {
+		return Step{}, d.err
+	}
+	if d.pc >= len(d.prog) {
+		return Step{}, ErrHalted
+	}
+	instr := d.prog[d.pc]
+	input := -1
+	if instr.Op == OpInp {
+		input = d.m.InputsRead()
+	}
+	if err := d.m.Exec(instr); err != nil {
+		d.err = &ExecError{Index: d.pc, Instr: instr, Err: err}
+		return Step{}, d.err
+	}
+	s := Step{
+		Index: d.pc,
+		Instr: instr,
+		Input: input,
+		Regs:  d.m.Regs(),
+	}
+	d.Trace = append(d.Trace, s)
+	d.pc++
+	return s, nil
+}
+
+// Continue runs at least one instruction, and then until the next breakpoint
+// or until the program halts. It returns whether it stopped at a breakpoint.
+func (d *Debugger) Continue() (bool, error) {
+	for {
+		if _, err := d.Step(); err != nil {
+			return false, err
+		}
+		if d.pc >= len(d.prog) {
+			return false, nil
+		}
+		if d.atBreak() {
+			return true, nil
+		}
+	}
+}
+
+// TraceProgram runs the program, and returns every step run before it halts
+// or crashes
+func TraceProgram(prog Program, stdin []int) ([]Step, error) {
+	d := NewDebugger(prog, stdin)
+	if _, err := d.Continue(); err != nil && !errors.Is(err, ErrHalted) {
+		return d.Trace, err
+	}
+	return d.Trace, nil
+}
+
+// SummarizeDigits returns the value of z before and after each input of a
+// trace. The z before an input is the z after the step before it.
+func SummarizeDigits(trace []Step) []DigitSummary {
+	var sums []DigitSummary
+	z := 0
+	for _, i := range trace {
+		if i.Input >= 0 {
+			sums = append(sums, DigitSummary{
+				Input: i.Input,
+				Index: i.Index,
+				Value: i.Regs[i.Instr.Arg[0].Val],
+				ZIn:   z,
+			})
+		}
+		z = i.Regs[RegZ]
+		if len(sums) > 0 {
+			sums[len(sums)-1].ZOut = z
+		}
+	}
+	return sums
+}
+
+func writeCSV(w io.Writer, header []string, rows [][]string) error {
+	c := csv.NewWriter(w)
+	if err := c.Write(header); err != nil {
+		return err
+	}
+	if err := c.WriteAll(rows); err != nil {
+		return err
+	}
+	return c.Error()
+}
+
+// WriteTraceCSV writes each step of a trace with the registers after it as
+// CSV, where instructions and inputs are 1-indexed
+func WriteTraceCSV(w io.Writer, trace []Step) error {
+	rows := make([][]string, 0, len(trace))
+	for _, i := range trace {
+		input := ""
+		if i.Input >= 0 {
+			input = strconv.Itoa(i.Input + 1)
+		}
+		row := []string{strconv.Itoa(i.Index + 1), i.Instr.String(), input}
+		for _, r := range i.Regs {
+			row = append(row, strconv.Itoa(r))
+		}
+		rows = append(rows, row)
+	}
+	return writeCSV(w, []string{"instruction", "op", "input", "w", "x", "y", "z"}, rows)
+}
+
+// WriteSummaryCSV writes the z before and after each input as CSV, where
+// inputs and instructions are 1-indexed
+func WriteSummaryCSV(w io.Writer, sums []DigitSummary) error {
+	rows := make([][]string, 0, len(sums))
+	for _, i := range sums {
+		rows = append(rows, []string{
+			strconv.Itoa(i.Input + 1),
+			strconv.Itoa(i.Index + 1),
+			strconv.Itoa(i.Value),
+			strconv.Itoa(i.ZIn),
+			strconv.Itoa(i.ZOut),
+		})
+	}
+	return writeCSV(w, []string{"input", "instruction", "value", "z_in", "z_out"}, rows)
+}
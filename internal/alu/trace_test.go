@@ -0,0 +1,103 @@
+package alu
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTraceProgram(t *testing.T) {
+	rng := rand.New(rand.NewSource(23))
+	m := NewMachine(nil)
+	for n := 0; n < 200; n++ {
+		prog := randomProgram(rng, 30)
+		stdin := randomInputs(rng, prog.Inputs())
+		trace, err := TraceProgram(prog, stdin)
+		m.Reset(stdin)
+		for k, i := range prog {
+			if execErr := m.Exec(i); execErr != nil {
+				if len(trace) != k {
+					t.Fatalf("want %d steps before the crash, got %d\n%s", k, len(trace), prog)
+				}
+				var e *ExecError
+				if !errors.As(err, &e) || e.Index != k {
+					t.Fatalf("want crash at instruction %d, got %v\n%s", k+1, err, prog)
+				}
+				break
+			}
+			if s := trace[k]; s.Index != k || s.Instr != i || s.Regs != m.Regs() {
+				t.Fatalf("instruction %d: want %v, got %v at %d with %v\n%s", k+1, m.Regs(), s.Instr, s.Index+1, s.Regs, prog)
+			}
+		}
+	}
+}
+
+func TestDebugger(t *testing.T) {
+	prog, err := Parse(strings.NewReader("inp x\nadd z x\nmul z 2\ninp y\nadd z y\ndiv z 0\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDebugger(prog, []int{3, 4})
+	d.BreakOnInp(true)
+	d.Break(4)
+	var pcs []int
+	for {
+		brk, err := d.Continue()
+		if err != nil {
+			if !errors.Is(err, ErrDivZero) {
+				t.Fatalf("want error %v, got %v", ErrDivZero, err)
+			}
+			break
+		}
+		if !brk {
+			t.Fatal("want the program to crash")
+		}
+		pcs = append(pcs, d.PC())
+	}
+	if want := []int{3, 4}; !reflect.DeepEqual(pcs, want) {
+		t.Errorf("want breaks at %v, got %v", want, pcs)
+	}
+	if !d.Done() || d.PC() != 5 {
+		t.Errorf("want crash at 5, got done %t at %d", d.Done(), d.PC())
+	}
+	if want := [NumRegs]int{0, 3, 4, 10}; d.Regs() != want {
+		t.Errorf("want registers %v, got %v", want, d.Regs())
+	}
+	if _, err := d.Step(); !errors.Is(err, ErrDivZero) {
+		t.Errorf("want error %v after the crash, got %v", ErrDivZero, err)
+	}
+
+	d = NewDebugger(prog[:5], []int{3, 4})
+	for k := 0; k < 5; k++ {
+		if _, err := d.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := d.Step(); !errors.Is(err, ErrHalted) {
+		t.Errorf("want error %v, got %v", ErrHalted, err)
+	}
+
+	want := []DigitSummary{
+		{Input: 0, Index: 0, Value: 3, ZIn: 0, ZOut: 6},
+		{Input: 1, Index: 3, Value: 4, ZIn: 6, ZOut: 10},
+	}
+	if got := SummarizeDigits(d.Trace); !reflect.DeepEqual(got, want) {
+		t.Errorf("want summary %v, got %v", want, got)
+	}
+	b := &strings.Builder{}
+	if err := WriteSummaryCSV(b, want); err != nil {
+		t.Fatal(err)
+	}
+	if want := "input,instruction,value,z_in,z_out\n1,1,3,0,6\n2,4,4,6,10\n"; b.String() != want {
+		t.Errorf("want csv %q, got %q", want, b.String())
+	}
+	b.Reset()
+	if err := WriteTraceCSV(b, d.Trace[:2]); err != nil {
+		t.Fatal(err)
+	}
+	if want := "instruction,op,input,w,x,y,z\n1,inp x,1,0,3,0,0\n2,add z x,,0,3,0,3\n"; b.String() != want {
+		t.Errorf("want csv %q, got %q", want, b.String())
+	}
+}
@@ -0,0 +1,144 @@
+// Package answers checks solver output against a manifest of expected
+// answers for each bundled input
+package answers
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+const (
+	// DefaultName is the name of the manifest at the repository root
+	DefaultName = "answers.json"
+)
+
+type (
+	// Expected holds the expected answers to both parts for an input, where an
+	// empty answer is one that is absent
+	Expected struct {
+		Part1 string `json:"part1,omitempty"`
+		Part2 string `json:"part2,omitempty"`
+	}
+
+	// Manifest maps a day directory name to its input file names and their
+	// expected answers
+	Manifest map[string]map[string]Expected
+
+	// Entry is a single input in a manifest
+	Entry struct {
+		Dir   string
+		Input string
+		Expected
+	}
+
+	// Mismatch is an answer which differs from the manifest
+	Mismatch struct {
+		Part int
+		Want string
+		Got  string
+	}
+)
+
+// Load reads a manifest from the named file
+func Load(name string) (Manifest, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	m := Manifest{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("Invalid manifest %s: %w", name, err)
+	}
+	return m, nil
+}
+
+// Entries returns all inputs in the manifest sorted by day then input
+func (m Manifest) Entries() []Entry {
+	var entries []Entry
+	for dir, inputs := range m {
+		for name, v := range inputs {
+			entries = append(entries, Entry{
+				Dir:      dir,
+				Input:    name,
+				Expected: v,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Dir != entries[j].Dir {
+			return entries[i].Dir < entries[j].Dir
+		}
+		return entries[i].Input < entries[j].Input
+	})
+	return entries
+}
+
+// DirName returns the directory name of a day
+func DirName(day int) string {
+	return fmt.Sprintf("day%02d", day)
+}
+
+// Day returns the day number of the entry's directory
+func (e Entry) Day() (int, error) {
+	day, err := strconv.Atoi(strings.TrimPrefix(e.Dir, "day"))
+	if err != nil {
+		return 0, fmt.Errorf("Invalid day directory %s: %w", e.Dir, err)
+	}
+	return day, nil
+}
+
+// Path returns the path of the entry's input relative to root
+func (e Entry) Path(root string) string {
+	return filepath.Join(root, e.Dir, e.Input)
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("part %d: want %q, got %q", m.Part, m.Want, m.Got)
+}
+
+// Compare returns the answers which differ from the expected ones
+func (e Expected) Compare(part1, part2 solver.Answer) []Mismatch {
+	var mismatches []Mismatch
+	if got := part1.String(); got != e.Part1 {
+		mismatches = append(mismatches, Mismatch{
+			Part: 1,
+			Want: e.Part1,
+			Got:  got,
+		})
+	}
+	if got := part2.String(); got != e.Part2 {
+		mismatches = append(mismatches, Mismatch{
+			Part: 2,
+			Want: e.Part2,
+			Got:  got,
+		})
+	}
+	return mismatches
+}
+
+// Check runs the solver on the entry's input relative to root and returns
+// the answers which differ from the manifest
+func Check(s solver.Solver, root string, e Entry) (_ []Mismatch, retErr error) {
+	file, err := input.Open(e.Path(root))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	part1, part2, err := s.Solve(file)
+	if err != nil {
+		return nil, input.WithFile(err, e.Path(root))
+	}
+	return e.Compare(part1, part2), nil
+}
@@ -0,0 +1,293 @@
+// Package bench times solvers and summarizes the results
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+)
+
+type (
+	// Options controls how many times a solver is run
+	Options struct {
+		// Warmup is the number of untimed runs before measuring
+		Warmup int
+		// MinRuns is the minimum number of timed runs
+		MinRuns int
+		// MinTime is the minimum total time spent on timed runs
+		MinTime time.Duration
+	}
+
+	// Stats summarizes run times in seconds
+	Stats struct {
+		Mean   float64 `json:"mean"`
+		Stddev float64 `json:"stddev"`
+		Median float64 `json:"median"`
+		Min    float64 `json:"min"`
+		Max    float64 `json:"max"`
+	}
+
+	// Result is the timing of one day, or of one part of a day when Part is
+	// nonzero
+	Result struct {
+		Lang string `json:"lang"`
+		Day  int    `json:"day"`
+		Part int    `json:"part,omitempty"`
+		Stats
+		Times []float64 `json:"times"`
+	}
+
+	// Report is the exported form of a set of results
+	Report struct {
+		Results []Result `json:"results"`
+	}
+
+	// Comparison pairs a result with its baseline
+	Comparison struct {
+		Lang     string
+		Day      int
+		Part     int
+		Baseline Stats
+		Current  Stats
+	}
+)
+
+const (
+	tableFormat = "%-8s %-8s %32s %32s %32s %32s %32s\n"
+)
+
+// DefaultOptions matches the defaults of the previous hyperfine based
+// benchmark script
+func DefaultOptions() Options {
+	return Options{
+		Warmup:  8,
+		MinRuns: 10,
+		MinTime: 3 * time.Second,
+	}
+}
+
+// Measure runs fn until both the minimum number of runs and minimum time are
+// reached, returning the time of each run in seconds
+func Measure(opts Options, fn func() error) ([]float64, error) {
+	for i := 0; i < opts.Warmup; i++ {
+		if err := fn(); err != nil {
+			return nil, err
+		}
+	}
+	var times []float64
+	var total time.Duration
+	for len(times) < opts.MinRuns || total < opts.MinTime {
+		start := time.Now()
+		if err := fn(); err != nil {
+			return nil, err
+		}
+		d := time.Since(start)
+		total += d
+		times = append(times, d.Seconds())
+	}
+	return times, nil
+}
+
+// Summarize computes the stats of a set of run times
+func Summarize(times []float64) Stats {
+	if len(times) == 0 {
+		return Stats{}
+	}
+	sorted := make([]float64, len(times))
+	copy(sorted, times)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for _, i := range sorted {
+		sum += i
+	}
+	mean := sum / float64(len(sorted))
+
+	stddev := 0.0
+	if len(sorted) > 1 {
+		sq := 0.0
+		for _, i := range sorted {
+			sq += (i - mean) * (i - mean)
+		}
+		stddev = math.Sqrt(sq / float64(len(sorted)-1))
+	}
+
+	l := len(sorted)
+	median := sorted[l/2]
+	if l%2 == 0 {
+		median = (sorted[l/2-1] + sorted[l/2]) / 2
+	}
+
+	return Stats{
+		Mean:   mean,
+		Stddev: stddev,
+		Median: median,
+		Min:    sorted[0],
+		Max:    sorted[l-1],
+	}
+}
+
+// NewResult summarizes the run times of a day or part
+func NewResult(lang string, day, part int, times []float64) Result {
+	return Result{
+		Lang:  lang,
+		Day:   day,
+		Part:  part,
+		Stats: Summarize(times),
+		Times: times,
+	}
+}
+
+// Label is the day column of the table, with the part appended if present
+func (r Result) Label() string {
+	return label(r.Day, r.Part)
+}
+
+func label(day, part int) string {
+	s := fmt.Sprintf("%02d", day)
+	if part != 0 {
+		s += "." + strconv.Itoa(part)
+	}
+	return s
+}
+
+func formatSeconds(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// WriteHeader writes the header row of the results table
+func WriteHeader(w io.Writer) {
+	fmt.Fprintf(w, tableFormat, "lang", "day", "mean", "stddev", "median", "min", "max")
+}
+
+// WriteRow writes a single result as a table row
+func WriteRow(w io.Writer, r Result) {
+	writeRow(w, r.Lang, r.Label(), r.Stats)
+}
+
+func writeRow(w io.Writer, lang, day string, s Stats) {
+	fmt.Fprintf(w, tableFormat, lang, day, formatSeconds(s.Mean), formatSeconds(s.Stddev), formatSeconds(s.Median), formatSeconds(s.Min), formatSeconds(s.Max))
+}
+
+// Total sums the stats of results of the same language, combining standard
+// deviations as independent variables
+func Total(results []Result) Stats {
+	var t Stats
+	variance := 0.0
+	for _, i := range results {
+		t.Mean += i.Mean
+		variance += i.Stddev * i.Stddev
+		t.Median += i.Median
+		t.Min += i.Min
+		t.Max += i.Max
+	}
+	t.Stddev = math.Sqrt(variance)
+	return t
+}
+
+// WriteTotals writes a total row for each language in the results
+func WriteTotals(w io.Writer, results []Result) {
+	var langs []string
+	byLang := map[string][]Result{}
+	for _, i := range results {
+		if _, ok := byLang[i.Lang]; !ok {
+			langs = append(langs, i.Lang)
+		}
+		byLang[i.Lang] = append(byLang[i.Lang], i)
+	}
+	for _, i := range langs {
+		writeRow(w, i, "total", Total(byLang[i]))
+	}
+}
+
+// WriteJSON exports results
+func WriteJSON(w io.Writer, results []Result) error {
+	b, err := json.MarshalIndent(Report{Results: results}, "", "  ")
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	_, err = w.Write(b)
+	return err
+}
+
+// Export writes results to the file name
+func Export(name string, results []Result) (retErr error) {
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	return WriteJSON(file, results)
+}
+
+// Load reads results previously written by Export
+func Load(name string) ([]Result, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, fmt.Errorf("Invalid benchmark file %s: %w", name, err)
+	}
+	return r.Results, nil
+}
+
+type (
+	resultKey struct {
+		lang string
+		day  int
+		part int
+	}
+)
+
+// Compare pairs each current result with the baseline result of the same
+// language, day, and part, skipping results without a baseline
+func Compare(baseline, current []Result) []Comparison {
+	base := map[resultKey]Stats{}
+	for _, i := range baseline {
+		base[resultKey{i.Lang, i.Day, i.Part}] = i.Stats
+	}
+	var comps []Comparison
+	for _, i := range current {
+		b, ok := base[resultKey{i.Lang, i.Day, i.Part}]
+		if !ok {
+			continue
+		}
+		comps = append(comps, Comparison{
+			Lang:     i.Lang,
+			Day:      i.Day,
+			Part:     i.Part,
+			Baseline: b,
+			Current:  i.Stats,
+		})
+	}
+	return comps
+}
+
+// Ratio is the current mean relative to the baseline mean
+func (c Comparison) Ratio() float64 {
+	if c.Baseline.Mean == 0 {
+		return math.Inf(1)
+	}
+	return c.Current.Mean / c.Baseline.Mean
+}
+
+// WriteComparisons writes a table of current against baseline means
+func WriteComparisons(w io.Writer, comps []Comparison) {
+	const format = "%-8s %-8s %32s %32s %16s\n"
+	fmt.Fprintf(w, format, "lang", "day", "baseline", "mean", "ratio")
+	for _, i := range comps {
+		fmt.Fprintf(w, format, i.Lang, label(i.Day, i.Part), formatSeconds(i.Baseline.Mean), formatSeconds(i.Current.Mean), strconv.FormatFloat(i.Ratio(), 'f', 3, 64))
+	}
+}
@@ -0,0 +1,57 @@
+package bench
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSummarize(t *testing.T) {
+	s := Summarize([]float64{4, 1, 3, 2})
+	if s.Mean != 2.5 || s.Median != 2.5 || s.Min != 1 || s.Max != 4 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+	if s.Stddev < 1.29 || s.Stddev > 1.30 {
+		t.Errorf("unexpected stddev %v", s.Stddev)
+	}
+	if s := Summarize([]float64{3, 1, 2}); s.Median != 2 || s.Stddev != 1 {
+		t.Errorf("unexpected stats %+v", s)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := []Result{
+		NewResult("go", 1, 0, []float64{2}),
+		NewResult("go", 2, 0, []float64{2}),
+	}
+	current := []Result{
+		NewResult("go", 1, 0, []float64{1}),
+		NewResult("go", 2, 1, []float64{1}),
+	}
+	comps := Compare(baseline, current)
+	if len(comps) != 1 {
+		t.Fatalf("want 1 comparison, got %d", len(comps))
+	}
+	if r := comps[0].Ratio(); r != 0.5 {
+		t.Errorf("want ratio 0.5, got %v", r)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	results := []Result{NewResult("go", 14, 2, []float64{0.5, 0.25})}
+	var b bytes.Buffer
+	if err := WriteJSON(&b, results); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(b.String(), `"part": 2`) {
+		t.Errorf("missing part in %s", b.String())
+	}
+
+	var table bytes.Buffer
+	WriteRow(&table, results[0])
+	WriteTotals(&table, results)
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "go       14.2 ") || !strings.HasPrefix(lines[1], "go       total") {
+		t.Errorf("unexpected table %q", table.String())
+	}
+}
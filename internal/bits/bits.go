@@ -0,0 +1,317 @@
+// Package bits decodes the BITS transmissions of day 16 as trees of packets
+package bits
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrTruncated   = errors.New("Transmission ends mid packet")
+	ErrOverrun     = errors.New("Sub-packets overrun their length")
+	ErrOverflow    = errors.New("Literal overflows an int")
+	ErrTrailing    = errors.New("Nonzero bits after the outermost packet")
+	ErrOperands    = errors.New("Wrong number of sub-packets")
+	ErrInvalidType = errors.New("Invalid packet type")
+)
+
+const (
+	versionBits = 3
+	typeBits    = 3
+	groupBits   = 5
+	// lengthBits0 and lengthBits1 are the widths of the lengths of each
+	// length type
+	lengthBits0 = 15
+	lengthBits1 = 11
+)
+
+type (
+	// Type is the type ID of a packet
+	Type int
+
+	// Packet is a literal value, or an operator on its sub-packets
+	Packet struct {
+		Version int
+		Type    Type
+		// Value is the value of a literal packet
+		Value int
+		// LengthType is how an operator packet counts its sub-packets: 0 for
+		// their total length in bits, and 1 for their number
+		LengthType int
+		// Children are the sub-packets of an operator packet
+		Children []*Packet
+		// Start and End are the 0-indexed bit offsets of the first bit of the
+		// packet and of the bit after it. They are set by Decode.
+		Start int
+		End   int
+	}
+
+	// DecodeError is a malformed packet
+	DecodeError struct {
+		// Bit is the 0-indexed bit offset where decoding failed
+		Bit int
+		// Packet is the 0-indexed bit offset of the packet being decoded
+		Packet int
+		Err    error
+	}
+
+	// reader reads big endian fields of bits
+	reader struct {
+		data []byte
+		pos  int
+	}
+)
+
+const (
+	TypeSum Type = iota
+	TypeProduct
+	TypeMin
+	TypeMax
+	TypeLiteral
+	TypeGreater
+	TypeLess
+	TypeEqual
+)
+
+var typeNames = [...]string{
+	TypeSum:     "sum",
+	TypeProduct: "product",
+	TypeMin:     "min",
+	TypeMax:     "max",
+	TypeLiteral: "literal",
+	TypeGreater: "gt",
+	TypeLess:    "lt",
+	TypeEqual:   "eq",
+}
+
+func (t Type) String() string {
+	if t < 0 || int(t) >= len(typeNames) {
+		return "type(" + strconv.Itoa(int(t)) + ")"
+	}
+	return typeNames[t]
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("bit %d of packet at bit %d: %v", e.Bit, e.Packet, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+func (r *reader) remaining() int {
+	return 8*len(r.data) - r.pos
+}
+
+// read reads an n bit field, where n is at most 63
+func (r *reader) read(n int) (int, bool) {
+	if r.remaining() < n {
+		return 0, false
+	}
+	k := 0
+	for i := 0; i < n; i++ {
+		b := r.data[r.pos/8] >> (7 - r.pos%8) & 1
+		k = k<<1 | int(b)
+		r.pos++
+	}
+	return k, true
+}
+
+// decodePacket decodes the packet starting at the bit offset of the reader
+func decodePacket(r *reader) (*Packet, error) {
+	p := &Packet{
+		Start: r.pos,
+	}
+	fail := func(bit int, err error) error {
+		return &DecodeError{Bit: bit, Packet: p.Start, Err: err}
+	}
+	read := func(n int) (int, error) {
+		k, ok := r.read(n)
+		if !ok {
+			return 0, fail(r.pos, fmt.Errorf("%w: want %d bits, have %d", ErrTruncated, n, r.remaining()))
+		}
+		return k, nil
+	}
+	var err error
+	if p.Version, err = read(versionBits); err != nil {
+		return nil, err
+	}
+	t, err := read(typeBits)
+	if err != nil {
+		return nil, err
+	}
+	p.Type = Type(t)
+	if p.Type == TypeLiteral {
+		for {
+			pos := r.pos
+			group, err := read(groupBits)
+			if err != nil {
+				return nil, err
+			}
+			if p.Value > math.MaxInt>>(groupBits-1) {
+				return nil, fail(pos, ErrOverflow)
+			}
+			p.Value = p.Value<<(groupBits-1) | group&(1<<(groupBits-1)-1)
+			if group>>(groupBits-1) == 0 {
+				break
+			}
+		}
+		p.End = r.pos
+		return p, nil
+	}
+	if p.LengthType, err = read(1); err != nil {
+		return nil, err
+	}
+	if p.LengthType == 0 {
+		l, err := read(lengthBits0)
+		if err != nil {
+			return nil, err
+		}
+		end := r.pos + l
+		if end > 8*len(r.data) {
+			return nil, fail(r.pos, fmt.Errorf("%w: sub-packets of %d bits, have %d", ErrTruncated, l, r.remaining()))
+		}
+		for r.pos < end {
+			child, err := decodePacket(r)
+			if err != nil {
+				return nil, err
+			}
+			if r.pos > end {
+				return nil, fail(child.Start, fmt.Errorf("%w: sub-packet ends at bit %d, past %d", ErrOverrun, r.pos, end))
+			}
+			p.Children = append(p.Children, child)
+		}
+	} else {
+		l, err := read(lengthBits1)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < l; i++ {
+			child, err := decodePacket(r)
+			if err != nil {
+				return nil, err
+			}
+			p.Children = append(p.Children, child)
+		}
+	}
+	p.End = r.pos
+	return p, nil
+}
+
+// Decode decodes the outermost packet of a transmission, where any bits after
+// it must be zero
+func Decode(data []byte) (*Packet, error) {
+	r := &reader{data: data}
+	p, err := decodePacket(r)
+	if err != nil {
+		return nil, err
+	}
+	for r.remaining() > 0 {
+		pos := r.pos
+		if k, _ := r.read(1); k != 0 {
+			return nil, &DecodeError{Bit: pos, Packet: p.Start, Err: ErrTrailing}
+		}
+	}
+	return p, nil
+}
+
+// VersionSum returns the sum of the versions of the packet and every packet
+// within it
+func (p *Packet) VersionSum() int {
+	k := p.Version
+	for _, i := range p.Children {
+		k += i.VersionSum()
+	}
+	return k
+}
+
+// Eval returns the value of the packet, where sum, product, min, and max take
+// at least one sub-packet, and the comparisons take exactly two
+func (p *Packet) Eval() (int, error) {
+	if p.Type == TypeLiteral {
+		return p.Value, nil
+	}
+	switch p.Type {
+	case TypeSum, TypeProduct, TypeMin, TypeMax:
+		if len(p.Children) == 0 {
+			return 0, fmt.Errorf("%w: %s packet at bit %d has none", ErrOperands, p.Type, p.Start)
+		}
+	case TypeGreater, TypeLess, TypeEqual:
+		if len(p.Children) != 2 {
+			return 0, fmt.Errorf("%w: %s packet at bit %d has %d, not 2", ErrOperands, p.Type, p.Start, len(p.Children))
+		}
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrInvalidType, p.Type)
+	}
+	vals := make([]int, 0, len(p.Children))
+	for _, i := range p.Children {
+		v, err := i.Eval()
+		if err != nil {
+			return 0, err
+		}
+		vals = append(vals, v)
+	}
+	k := vals[0]
+	switch p.Type {
+	case TypeSum:
+		for _, i := range vals[1:] {
+			k += i
+		}
+	case TypeProduct:
+		for _, i := range vals[1:] {
+			k *= i
+		}
+	case TypeMin:
+		for _, i := range vals[1:] {
+			if i < k {
+				k = i
+			}
+		}
+	case TypeMax:
+		for _, i := range vals[1:] {
+			if i > k {
+				k = i
+			}
+		}
+	case TypeGreater:
+		k = boolToInt(vals[0] > vals[1])
+	case TypeLess:
+		k = boolToInt(vals[0] < vals[1])
+	case TypeEqual:
+		k = boolToInt(vals[0] == vals[1])
+	}
+	return k, nil
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (p *Packet) writeTo(b *strings.Builder) {
+	if p.Type == TypeLiteral {
+		b.WriteString(strconv.Itoa(p.Value))
+		return
+	}
+	b.WriteString(p.Type.String())
+	b.WriteByte('(')
+	for n, i := range p.Children {
+		if n > 0 {
+			b.WriteString(", ")
+		}
+		i.writeTo(b)
+	}
+	b.WriteByte(')')
+}
+
+// String returns the packet as an expression such as sum(1, max(2, 3))
+func (p *Packet) String() string {
+	b := strings.Builder{}
+	p.writeTo(&b)
+	return b.String()
+}
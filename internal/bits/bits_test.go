@@ -0,0 +1,108 @@
+package bits
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func decodeHex(t *testing.T, s string) (*Packet, error) {
+	t.Helper()
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Decode(data)
+}
+
+func TestDecode(t *testing.T) {
+	for _, tc := range []struct {
+		hex        string
+		expr       string
+		versionSum int
+		val        int
+		// spans are the start and end of the packet and then each child
+		spans [][2]int
+	}{
+		{"D2FE28", "2021", 6, 2021, [][2]int{{0, 21}}},
+		{"38006F45291200", "lt(10, 20)", 9, 1, [][2]int{{0, 49}, {22, 33}, {33, 49}}},
+		{"EE00D40C823060", "max(1, 2, 3)", 14, 3, [][2]int{{0, 51}, {18, 29}, {29, 40}, {40, 51}}},
+		{"9C0141080250320F1802104A08", "eq(sum(1, 3), product(2, 2))", 20, 1, nil},
+		{"A0016C880162017C3686B18A3D4780", "sum(sum(sum(6, 6, 12, 15, 15)))", 31, 54, nil},
+	} {
+		p, err := decodeHex(t, tc.hex)
+		if err != nil {
+			t.Fatalf("%s: %v", tc.hex, err)
+		}
+		if got := p.String(); got != tc.expr {
+			t.Errorf("%s: want %s, got %s", tc.hex, tc.expr, got)
+		}
+		if got := p.VersionSum(); got != tc.versionSum {
+			t.Errorf("%s: want version sum %d, got %d", tc.hex, tc.versionSum, got)
+		}
+		val, err := p.Eval()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != tc.val {
+			t.Errorf("%s: want value %d, got %d", tc.hex, tc.val, val)
+		}
+		if len(tc.spans) == 0 {
+			continue
+		}
+		got := [][2]int{{p.Start, p.End}}
+		for _, i := range p.Children {
+			got = append(got, [2]int{i.Start, i.End})
+		}
+		if len(got) != len(tc.spans) {
+			t.Fatalf("%s: want %d spans, got %d", tc.hex, len(tc.spans), len(got))
+		}
+		for n, i := range tc.spans {
+			if got[n] != i {
+				t.Errorf("%s: want span %v, got %v", tc.hex, i, got[n])
+			}
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		hex string
+		err error
+		bit int
+	}{
+		{"D2FE", ErrTruncated, 16},
+		{"D2FE29", ErrTrailing, 23},
+		{"0000284080", ErrOverrun, 22},
+		{"13FFFFFFFFFFFFFFFFFFFC20", ErrOverflow, 81},
+		{"38006F4529", ErrTruncated, 22},
+	} {
+		_, err := decodeHex(t, tc.hex)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s: want error %v, got %v", tc.hex, tc.err, err)
+			continue
+		}
+		var e *DecodeError
+		if !errors.As(err, &e) || e.Bit != tc.bit {
+			t.Errorf("%s: want error at bit %d, got %v", tc.hex, tc.bit, err)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	lit := &Packet{Type: TypeLiteral, Value: 1}
+	for _, p := range []*Packet{
+		{Type: TypeSum},
+		{Type: TypeMin},
+		{Type: TypeGreater, Children: []*Packet{lit}},
+		{Type: TypeEqual, Children: []*Packet{lit, lit, lit}},
+		{Type: TypeProduct, Children: []*Packet{lit, {Type: TypeLess}}},
+	} {
+		if _, err := p.Eval(); !errors.Is(err, ErrOperands) {
+			t.Errorf("%s: want error %v, got %v", p, ErrOperands, err)
+		}
+	}
+	if _, err := (&Packet{Type: 9, Children: []*Packet{lit}}).Eval(); !errors.Is(err, ErrInvalidType) {
+		t.Errorf("want error %v, got %v", ErrInvalidType, err)
+	}
+}
@@ -0,0 +1,96 @@
+// Package cli dispatches the subcommands of a command line tool
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+var (
+	// ErrUsage is wrapped by errors in the args of a command, which exit with 2
+	ErrUsage = errors.New("Invalid usage")
+)
+
+type (
+	// Command is a subcommand, which is run with the args after its name
+	Command struct {
+		Name  string
+		Usage string
+		Run   func(args []string) error
+	}
+
+	// App is a tool whose first arg names the command to run
+	App struct {
+		Name     string
+		Commands []Command
+		// Footer is printed after the commands in the usage if it is set
+		Footer string
+	}
+)
+
+// Usage writes the usage of every command
+func (a App) Usage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: %s <command> [args]\n", a.Name)
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Commands:")
+	for _, i := range a.Commands {
+		fmt.Fprintln(w, "  "+i.Usage)
+	}
+	if a.Footer != "" {
+		fmt.Fprintln(w, "")
+		fmt.Fprintln(w, a.Footer)
+	}
+}
+
+// Run runs the command named by the first arg, and returns the exit code. It
+// is 0 on success or if help was requested, 2 for an unknown command or an
+// error wrapping ErrUsage, and 1 for any other error, which is written to w.
+func (a App) Run(args []string, w io.Writer) int {
+	if len(args) < 1 {
+		a.Usage(w)
+		return 2
+	}
+	for _, i := range a.Commands {
+		if i.Name != args[0] {
+			continue
+		}
+		if err := i.Run(args[1:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return 0
+			}
+			fmt.Fprintln(w, err)
+			if errors.Is(err, ErrUsage) {
+				return 2
+			}
+			return 1
+		}
+		return 0
+	}
+	a.Usage(w)
+	return 2
+}
+
+// Main runs the command named by the args of the process and exits
+func (a App) Main() {
+	os.Exit(a.Run(os.Args[1:], os.Stderr))
+}
+
+// ParseFlags parses flags which may be interleaved with positional args,
+// returning the positional args
+func ParseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return pos, nil
+		}
+		pos = append(pos, args[0])
+		args = args[1:]
+	}
+}
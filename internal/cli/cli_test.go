@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	n := fs.Int("n", 0, "")
+	v := fs.Bool("v", false, "")
+	pos, err := ParseFlags(fs, []string{"a", "-n", "3", "b", "-v", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(pos, ","); got != "a,b,c" {
+		t.Errorf("want positional args a,b,c, got %s", got)
+	}
+	if *n != 3 || !*v {
+		t.Errorf("want n=3 v=true, got n=%d v=%t", *n, *v)
+	}
+}
+
+func TestRun(t *testing.T) {
+	var ran []string
+	app := App{
+		Name: "test",
+		Commands: []Command{
+			{
+				Name:  "ok",
+				Usage: "ok [args]",
+				Run: func(args []string) error {
+					ran = append(ran, strings.Join(args, ","))
+					return nil
+				},
+			},
+			{
+				Name:  "usage",
+				Usage: "usage",
+				Run: func(args []string) error {
+					return fmt.Errorf("%w: bad args", ErrUsage)
+				},
+			},
+			{
+				Name:  "fail",
+				Usage: "fail",
+				Run: func(args []string) error {
+					return errors.New("failed")
+				},
+			},
+			{
+				Name:  "help",
+				Usage: "help",
+				Run: func(args []string) error {
+					return flag.ErrHelp
+				},
+			},
+		},
+		Footer: "footer",
+	}
+	for _, tc := range []struct {
+		args   []string
+		code   int
+		output string
+	}{
+		{[]string{"ok", "a", "b"}, 0, ""},
+		{[]string{"usage"}, 2, "Invalid usage: bad args\n"},
+		{[]string{"fail"}, 1, "failed\n"},
+		{[]string{"help"}, 0, ""},
+		{nil, 2, "Usage: test <command> [args]"},
+		{[]string{"missing"}, 2, "  ok [args]\n"},
+	} {
+		w := &bytes.Buffer{}
+		if code := app.Run(tc.args, w); code != tc.code {
+			t.Errorf("%v: want exit code %d, got %d", tc.args, tc.code, code)
+		}
+		if got := w.String(); (tc.output == "" && got != "") || !strings.Contains(got, tc.output) {
+			t.Errorf("%v: want output containing %q, got %q", tc.args, tc.output, got)
+		}
+	}
+	if len(ran) != 1 || ran[0] != "a,b" {
+		t.Errorf("want ok run with a,b, got %v", ran)
+	}
+	w := &bytes.Buffer{}
+	app.Usage(w)
+	if !strings.HasSuffix(w.String(), "\n\nfooter\n") {
+		t.Errorf("want usage ending with footer, got %q", w.String())
+	}
+}
@@ -0,0 +1,62 @@
+// Package days registers the solver for every day
+package days
+
+import (
+	"github.com/xorkevin/advent2021/day01"
+	"github.com/xorkevin/advent2021/day02"
+	"github.com/xorkevin/advent2021/day03"
+	"github.com/xorkevin/advent2021/day04"
+	"github.com/xorkevin/advent2021/day05"
+	"github.com/xorkevin/advent2021/day06"
+	"github.com/xorkevin/advent2021/day07"
+	"github.com/xorkevin/advent2021/day08"
+	"github.com/xorkevin/advent2021/day09"
+	"github.com/xorkevin/advent2021/day10"
+	"github.com/xorkevin/advent2021/day11"
+	"github.com/xorkevin/advent2021/day12"
+	"github.com/xorkevin/advent2021/day13"
+	"github.com/xorkevin/advent2021/day14"
+	"github.com/xorkevin/advent2021/day15"
+	"github.com/xorkevin/advent2021/day16"
+	"github.com/xorkevin/advent2021/day17"
+	"github.com/xorkevin/advent2021/day18"
+	"github.com/xorkevin/advent2021/day19"
+	"github.com/xorkevin/advent2021/day20"
+	"github.com/xorkevin/advent2021/day21"
+	"github.com/xorkevin/advent2021/day22"
+	"github.com/xorkevin/advent2021/day23"
+	"github.com/xorkevin/advent2021/day24"
+	"github.com/xorkevin/advent2021/day25"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+// Registry returns a registry of the solvers for every day
+func Registry() *solver.Registry {
+	r := solver.NewRegistry()
+	r.Register(1, solver.Parts{Part1: day01.Part1, Part2: day01.Part2})
+	r.Register(2, solver.Parts{Part1: day02.Part1, Part2: day02.Part2})
+	r.Register(3, solver.Parts{Part1: day03.Part1, Part2: day03.Part2})
+	r.Register(4, solver.Parts{Part1: day04.Part1, Part2: day04.Part2})
+	r.Register(5, solver.Parts{Part1: day05.Part1, Part2: day05.Part2})
+	r.Register(6, solver.Parts{Part1: day06.Part1, Part2: day06.Part2})
+	r.Register(7, solver.Parts{Part1: day07.Part1, Part2: day07.Part2})
+	r.Register(8, solver.Parts{Part1: day08.Part1, Part2: day08.Part2})
+	r.Register(9, solver.Parts{Part1: day09.Part1, Part2: day09.Part2})
+	r.Register(10, solver.Parts{Part1: day10.Part1, Part2: day10.Part2})
+	r.Register(11, solver.Parts{Part1: day11.Part1, Part2: day11.Part2})
+	r.Register(12, solver.Parts{Part1: day12.Part1, Part2: day12.Part2})
+	r.Register(13, solver.Parts{Part1: day13.Part1, Part2: day13.Part2})
+	r.Register(14, solver.Parts{Part1: day14.Part1, Part2: day14.Part2})
+	r.Register(15, solver.Parts{Part1: day15.Part1, Part2: day15.Part2})
+	r.Register(16, solver.Parts{Part1: day16.Part1, Part2: day16.Part2})
+	r.Register(17, solver.Parts{Part1: day17.Part1, Part2: day17.Part2})
+	r.Register(18, solver.Parts{Part1: day18.Part1, Part2: day18.Part2})
+	r.Register(19, solver.Parts{Part1: day19.Part1, Part2: day19.Part2})
+	r.Register(20, solver.Parts{Part1: day20.Part1, Part2: day20.Part2})
+	r.Register(21, solver.Parts{Part1: day21.Part1, Part2: day21.Part2})
+	r.Register(22, solver.Parts{Part1: day22.Part1, Part2: day22.Part2})
+	r.Register(23, solver.Parts{Part1: day23.Part1, Part2: day23.Part2})
+	r.Register(24, solver.Parts{Part1: day24.Part1, Part2: day24.Part2})
+	r.Register(25, solver.Parts{Part1: day25.Part1})
+	return r
+}
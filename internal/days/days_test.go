@@ -0,0 +1,53 @@
+package days
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+)
+
+const (
+	repoRoot = "../.."
+)
+
+func TestAnswers(t *testing.T) {
+	m, err := answers.Load(filepath.Join(repoRoot, answers.DefaultName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := Registry()
+	for _, e := range m.Entries() {
+		e := e
+		t.Run(e.Dir+"/"+e.Input, func(t *testing.T) {
+			day, err := e.Day()
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, ok := registry.Get(day)
+			if !ok {
+				t.Fatalf("No solver for %s", e.Dir)
+			}
+			mismatches, err := answers.Check(s, repoRoot, e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, i := range mismatches {
+				t.Error(i)
+			}
+		})
+	}
+}
+
+func TestManifestCoversRegistry(t *testing.T) {
+	m, err := answers.Load(filepath.Join(repoRoot, answers.DefaultName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range Registry().Days() {
+		dir := answers.DirName(i)
+		if len(m[dir]) == 0 {
+			t.Errorf("No answers for %s", dir)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package fetch
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// SumName is the name of the checksum file at the root of a cache, in the
+	// format of sha256sum
+	SumName = "fetch.sum"
+
+	sumFormat = "<sha256 hex>  <path>"
+)
+
+var (
+	ErrModified   = errors.New("File has local edits")
+	ErrInvalidSum = errors.New("Invalid checksum line")
+)
+
+const (
+	// StatusMissing is a file which does not exist
+	StatusMissing Status = iota
+	// StatusCached is a file whose contents match its recorded checksum
+	StatusCached
+	// StatusModified is a file which exists but has no checksum, or whose
+	// contents differ from its checksum
+	StatusModified
+)
+
+type (
+	// Status is the state of a file in the cache
+	Status int
+
+	// Cache stores fetched files under Root, recording their checksums so that
+	// local edits are never overwritten
+	Cache struct {
+		Root string
+	}
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusMissing:
+		return "missing"
+	case StatusCached:
+		return "cached"
+	case StatusModified:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
+func checksum(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// Sums reads the recorded checksums keyed by slash separated path
+func (c Cache) Sums() (_ map[string]string, retErr error) {
+	sums := map[string]string{}
+	name := filepath.Join(c.Root, SumName)
+	file, err := os.Open(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return sums, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	scanner := bufio.NewScanner(file)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		sum, path, ok := strings.Cut(line, "  ")
+		if !ok || len(sum) != sha256.Size*2 || path == "" {
+			return nil, fmt.Errorf("%s:%d: %w, expected %q", name, n, ErrInvalidSum, sumFormat)
+		}
+		sums[path] = sum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sums, nil
+}
+
+func (c Cache) writeSums(sums map[string]string) error {
+	paths := make([]string, 0, len(sums))
+	for k := range sums {
+		paths = append(paths, k)
+	}
+	sort.Strings(paths)
+	b := strings.Builder{}
+	for _, i := range paths {
+		b.WriteString(sums[i])
+		b.WriteString("  ")
+		b.WriteString(i)
+		b.WriteString("\n")
+	}
+	return os.WriteFile(filepath.Join(c.Root, SumName), []byte(b.String()), 0644)
+}
+
+// Status returns the state of the file at the slash separated path
+func (c Cache) Status(path string) (Status, error) {
+	b, err := os.ReadFile(filepath.Join(c.Root, filepath.FromSlash(path)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return StatusMissing, nil
+		}
+		return 0, err
+	}
+	sums, err := c.Sums()
+	if err != nil {
+		return 0, err
+	}
+	if sum, ok := sums[path]; ok && sum == checksum(b) {
+		return StatusCached, nil
+	}
+	return StatusModified, nil
+}
+
+// Store writes the file at the slash separated path and records its checksum.
+// It returns ErrModified rather than overwrite a file with local edits.
+func (c Cache) Store(path string, b []byte) error {
+	s, err := c.Status(path)
+	if err != nil {
+		return err
+	}
+	if s == StatusModified {
+		return fmt.Errorf("%w: %s", ErrModified, path)
+	}
+	name := filepath.Join(c.Root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(name, b, 0644); err != nil {
+		return err
+	}
+	sums, err := c.Sums()
+	if err != nil {
+		return err
+	}
+	sums[path] = checksum(b)
+	return c.writeSums(sums)
+}
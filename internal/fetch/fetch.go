@@ -0,0 +1,159 @@
+// Package fetch downloads puzzle inputs and descriptions, and caches them in
+// the day directories
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+	"github.com/xorkevin/advent2021/internal/input"
+)
+
+const (
+	// DefaultBaseURL is the advent of code site
+	DefaultBaseURL = "https://adventofcode.com"
+	// Year is the event the puzzles are fetched from
+	Year = 2021
+	// PuzzleName is the name of the puzzle description in a day directory
+	PuzzleName = "README.md"
+
+	userAgent = "github.com/xorkevin/advent2021 advent fetch"
+	maxBody   = 1 << 20
+)
+
+var (
+	ErrNoSession = errors.New("No session token")
+	ErrStatus    = errors.New("Unexpected response status")
+	ErrTooLarge  = errors.New("Response too large")
+)
+
+type (
+	// Client sends http requests, and is satisfied by *http.Client
+	Client interface {
+		Do(req *http.Request) (*http.Response, error)
+	}
+
+	// Fetcher downloads puzzles with a session token
+	Fetcher struct {
+		Client  Client
+		BaseURL string
+		Session string
+	}
+
+	// Update is what Day did with a file
+	Update struct {
+		Name   string
+		Status Status
+		// Fetched is whether the file was downloaded
+		Fetched bool
+	}
+)
+
+// NewFetcher returns a fetcher for the advent of code site using the default
+// http client
+func NewFetcher(session string) *Fetcher {
+	return &Fetcher{
+		Client:  http.DefaultClient,
+		BaseURL: DefaultBaseURL,
+		Session: session,
+	}
+}
+
+func (f *Fetcher) get(ctx context.Context, path string) (_ []byte, retErr error) {
+	if f.Session == "" {
+		return nil, ErrNoSession
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(f.BaseURL, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: f.Session})
+	res, err := f.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	// one byte past the limit is read so that a body which exceeds it is not
+	// mistaken for a complete one
+	b, err := io.ReadAll(io.LimitReader(res.Body, maxBody+1))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		if len(b) > maxBody {
+			b = b[:maxBody]
+		}
+		return nil, fmt.Errorf("%w %d for %s: %s", ErrStatus, res.StatusCode, path, strings.TrimSpace(string(b)))
+	}
+	if len(b) > maxBody {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrTooLarge, path, maxBody)
+	}
+	return b, nil
+}
+
+// Input downloads the puzzle input of the day
+func (f *Fetcher) Input(ctx context.Context, day int) ([]byte, error) {
+	return f.get(ctx, fmt.Sprintf("/%d/day/%d/input", Year, day))
+}
+
+// Puzzle downloads the puzzle description of the day, and converts it to the
+// plain text format of the README in each day directory
+func (f *Fetcher) Puzzle(ctx context.Context, day int) ([]byte, error) {
+	b, err := f.get(ctx, fmt.Sprintf("/%d/day/%d", Year, day))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(Markdown(string(b))), nil
+}
+
+// Day fetches the input and puzzle description of the day into its directory
+// in the cache. Files which are already cached are only downloaded again if
+// refresh is set. Nothing is downloaded if any of the files has local edits.
+func Day(ctx context.Context, f *Fetcher, c Cache, day int, refresh bool) ([]Update, error) {
+	dir := answers.DirName(day)
+	files := []struct {
+		name string
+		get  func(ctx context.Context, day int) ([]byte, error)
+	}{
+		{dir + "/" + input.DefaultName, f.Input},
+		{dir + "/" + PuzzleName, f.Puzzle},
+	}
+	updates := make([]Update, 0, len(files))
+	for _, i := range files {
+		s, err := c.Status(i.name)
+		if err != nil {
+			return nil, err
+		}
+		if s == StatusModified {
+			return nil, fmt.Errorf("%w: %s", ErrModified, i.name)
+		}
+		updates = append(updates, Update{
+			Name:   i.name,
+			Status: s,
+		})
+	}
+	for n, i := range files {
+		if updates[n].Status == StatusCached && !refresh {
+			continue
+		}
+		b, err := i.get(ctx, day)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.Store(i.name, b); err != nil {
+			return nil, err
+		}
+		updates[n].Fetched = true
+	}
+	return updates, nil
+}
@@ -0,0 +1,236 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testSession = "abc123"
+	testInput   = "199\n200\n208\n"
+	testPage    = `<!DOCTYPE html>
+<html lang="en-us">
+<body>
+<header><h1 class="title-global"><a href="/">Advent of Code</a></h1></header>
+<main>
+<article class="day-desc"><h2>--- Day 1: Sonar Sweep ---</h2><p>You're minding your own business on a ship at sea when the overboard alarm goes off! Collect stars by solving puzzles. Each puzzle grants <em>one star</em>. Good luck!</p>
+<p>For example, suppose you had the following report:</p>
+<pre><code>199
+200
+<em>208</em>
+</code></pre>
+<ul>
+<li><code>forward X</code> increases the horizontal position by <code>X</code> units.</li>
+<li><code>down X</code> <em>increases</em> the depth by <code>X</code> units.</li>
+</ul>
+<p>In this example, there are <code><em>7</em></code> measurements that are larger &amp; deeper.</p>
+</article>
+<p>Your puzzle answer was <code>1516</code>.</p>
+<p>You can also <a href="/share">share</a> this puzzle.</p>
+</main>
+</body>
+</html>
+`
+	testMarkdown = "--- Day 1: Sonar Sweep ---\n" +
+		"\n" +
+		"You're minding your own business on a ship at sea when the overboard alarm goes\n" +
+		"off! Collect stars by solving puzzles. Each puzzle grants **one star**. Good\n" +
+		"luck!\n" +
+		"\n" +
+		"For example, suppose you had the following report:\n" +
+		"\n" +
+		"```\n" +
+		"199\n" +
+		"200\n" +
+		"208\n" +
+		"```\n" +
+		"\n" +
+		"- `forward X` increases the horizontal position by `X` units.\n" +
+		"- `down X` **increases** the depth by `X` units.\n" +
+		"\n" +
+		"In this example, there are **`7`** measurements that are larger & deeper.\n" +
+		"\n" +
+		"Your puzzle answer was `1516`.\n"
+)
+
+type (
+	testServer struct {
+		requests int
+		input    string
+	}
+)
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests++
+	if c, err := r.Cookie("session"); err != nil || c.Value != testSession {
+		http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+		return
+	}
+	switch r.URL.Path {
+	case "/2021/day/1/input":
+		w.Write([]byte(s.input))
+	case "/2021/day/1":
+		w.Write([]byte(testPage))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func newTestFetcher(t *testing.T, session string) (*Fetcher, *testServer) {
+	t.Helper()
+	handler := &testServer{input: testInput}
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &Fetcher{
+		Client:  srv.Client(),
+		BaseURL: srv.URL,
+		Session: session,
+	}, handler
+}
+
+func TestMarkdown(t *testing.T) {
+	if s := Markdown(testPage); s != testMarkdown {
+		t.Errorf("want:\n%s\ngot:\n%s", testMarkdown, s)
+	}
+}
+
+func TestDay(t *testing.T) {
+	ctx := context.Background()
+	f, srv := newTestFetcher(t, testSession)
+	c := Cache{Root: t.TempDir()}
+
+	updates, err := Day(ctx, f, c, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 2 || !updates[0].Fetched || !updates[1].Fetched {
+		t.Fatalf("want both files fetched, got %+v", updates)
+	}
+	b, err := os.ReadFile(filepath.Join(c.Root, "day01", "input.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != testInput {
+		t.Errorf("want input %q, got %q", testInput, b)
+	}
+	b, err = os.ReadFile(filepath.Join(c.Root, "day01", PuzzleName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != testMarkdown {
+		t.Errorf("want puzzle:\n%s\ngot:\n%s", testMarkdown, b)
+	}
+	sums, err := c.Sums()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sums) != 2 || sums["day01/input.txt"] != checksum([]byte(testInput)) {
+		t.Errorf("unexpected sums %v", sums)
+	}
+
+	// cached files are not downloaded again
+	srv.requests = 0
+	updates, err = Day(ctx, f, c, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if srv.requests != 0 {
+		t.Errorf("want no requests, got %d", srv.requests)
+	}
+	for _, i := range updates {
+		if i.Fetched || i.Status != StatusCached {
+			t.Errorf("want %s cached, got %+v", i.Name, i)
+		}
+	}
+
+	// unmodified files may be refreshed
+	srv.input = "1\n2\n"
+	if _, err := Day(ctx, f, c, 1, true); err != nil {
+		t.Fatal(err)
+	}
+	if srv.requests != 2 {
+		t.Errorf("want 2 requests, got %d", srv.requests)
+	}
+	if s, err := c.Status("day01/input.txt"); err != nil || s != StatusCached {
+		t.Errorf("want refreshed input cached, got %v %v", s, err)
+	}
+
+	// local edits are never overwritten
+	edited := []byte("local\n")
+	if err := os.WriteFile(filepath.Join(c.Root, "day01", "input.txt"), edited, 0644); err != nil {
+		t.Fatal(err)
+	}
+	srv.requests = 0
+	if _, err := Day(ctx, f, c, 1, true); !errors.Is(err, ErrModified) {
+		t.Errorf("want ErrModified, got %v", err)
+	}
+	if srv.requests != 0 {
+		t.Errorf("want no requests, got %d", srv.requests)
+	}
+	if b, err := os.ReadFile(filepath.Join(c.Root, "day01", "input.txt")); err != nil || string(b) != string(edited) {
+		t.Errorf("local edit was overwritten: %q %v", b, err)
+	}
+}
+
+func TestDayUntracked(t *testing.T) {
+	f, srv := newTestFetcher(t, testSession)
+	c := Cache{Root: t.TempDir()}
+	if err := os.MkdirAll(filepath.Join(c.Root, "day01"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(c.Root, "day01", PuzzleName), []byte("by hand\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Day(context.Background(), f, c, 1, false); !errors.Is(err, ErrModified) {
+		t.Errorf("want ErrModified, got %v", err)
+	}
+	if srv.requests != 0 {
+		t.Errorf("want no requests, got %d", srv.requests)
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	ctx := context.Background()
+	f, _ := newTestFetcher(t, "wrong")
+	_, err := f.Input(ctx, 1)
+	if !errors.Is(err, ErrStatus) {
+		t.Fatalf("want ErrStatus, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "Please log in") {
+		t.Errorf("error missing status and message: %v", err)
+	}
+
+	f, srv := newTestFetcher(t, "")
+	if _, err := f.Input(ctx, 1); !errors.Is(err, ErrNoSession) {
+		t.Errorf("want ErrNoSession, got %v", err)
+	}
+	if srv.requests != 0 {
+		t.Errorf("want no requests, got %d", srv.requests)
+	}
+
+	f, srv = newTestFetcher(t, testSession)
+	srv.input = strings.Repeat("1", maxBody)
+	if b, err := f.Input(ctx, 1); err != nil || len(b) != maxBody {
+		t.Errorf("want input of %d bytes, got %d bytes and %v", maxBody, len(b), err)
+	}
+	srv.input += "\n"
+	if _, err := f.Input(ctx, 1); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("want ErrTooLarge, got %v", err)
+	}
+}
+
+func TestSumsInvalid(t *testing.T) {
+	c := Cache{Root: t.TempDir()}
+	if err := os.WriteFile(filepath.Join(c.Root, SumName), []byte("abc day01/input.txt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Sums(); !errors.Is(err, ErrInvalidSum) {
+		t.Errorf("want ErrInvalidSum, got %v", err)
+	}
+}
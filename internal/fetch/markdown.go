@@ -0,0 +1,192 @@
+package fetch
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+const (
+	lineWidth = 80
+)
+
+var (
+	tagRegex      = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)[^>]*>`)
+	boldCodeRegex = regexp.MustCompile("`\\*\\*([^`]*)\\*\\*`")
+)
+
+type (
+	mdWriter struct {
+		lines     []string
+		text      strings.Builder
+		capture   bool
+		article   int
+		pre       bool
+		listDepth int
+	}
+)
+
+// blank ends the current block with an empty line
+func (w *mdWriter) blank() {
+	if len(w.lines) > 0 && w.lines[len(w.lines)-1] != "" {
+		w.lines = append(w.lines, "")
+	}
+}
+
+// inline returns the captured text with whitespace collapsed, and resets it
+func (w *mdWriter) inline() string {
+	s := strings.Join(strings.Fields(w.text.String()), " ")
+	w.text.Reset()
+	return boldCodeRegex.ReplaceAllString(s, "**`$1`**")
+}
+
+// wrap appends s wrapped to the line width, where the first line begins with
+// prefix and the rest with indent
+func (w *mdWriter) wrap(s, prefix, indent string) {
+	line := prefix
+	empty := true
+	for _, i := range strings.Fields(s) {
+		if !empty && len(line)+1+len(i) > lineWidth {
+			w.lines = append(w.lines, line)
+			line = indent
+			empty = true
+		}
+		if !empty {
+			line += " "
+		}
+		line += i
+		empty = false
+	}
+	if !empty {
+		w.lines = append(w.lines, line)
+	}
+}
+
+// item writes the captured text of a list item, if there is any
+func (w *mdWriter) item() {
+	s := w.inline()
+	if s == "" || w.article == 0 {
+		return
+	}
+	indent := strings.Repeat("  ", w.listDepth-1)
+	w.wrap(s, indent+"- ", indent+"  ")
+}
+
+func (w *mdWriter) open(tag string) {
+	switch tag {
+	case "article":
+		w.article++
+	case "h2", "p":
+		w.text.Reset()
+		w.capture = true
+	case "ul":
+		if w.listDepth == 0 {
+			w.blank()
+		} else {
+			w.item()
+		}
+		w.listDepth++
+	case "li":
+		w.item()
+		w.capture = true
+	case "pre":
+		w.text.Reset()
+		w.capture = true
+		w.pre = true
+	case "code":
+		if w.capture && !w.pre {
+			w.text.WriteString("`")
+		}
+	case "em":
+		if w.capture && !w.pre {
+			w.text.WriteString("**")
+		}
+	}
+}
+
+func (w *mdWriter) close(tag string) {
+	switch tag {
+	case "article":
+		w.article--
+	case "h2":
+		w.capture = false
+		if w.article > 0 {
+			w.blank()
+			w.lines = append(w.lines, w.inline())
+			w.blank()
+		}
+	case "p":
+		w.capture = false
+		s := w.inline()
+		// the answers to solved parts are given outside of the articles
+		if w.article > 0 || strings.HasPrefix(s, "Your puzzle answer was") {
+			w.blank()
+			w.wrap(s, "", "")
+			w.blank()
+		}
+	case "ul":
+		w.item()
+		w.listDepth--
+		if w.listDepth == 0 {
+			w.capture = false
+			w.blank()
+		}
+	case "li":
+		w.item()
+	case "pre":
+		w.capture = false
+		w.pre = false
+		s := strings.TrimRight(w.text.String(), "\n")
+		w.text.Reset()
+		if w.article == 0 {
+			return
+		}
+		w.blank()
+		w.lines = append(w.lines, "```")
+		w.lines = append(w.lines, strings.Split(s, "\n")...)
+		w.lines = append(w.lines, "```")
+		w.blank()
+	case "code":
+		if w.capture && !w.pre {
+			w.text.WriteString("`")
+		}
+	case "em":
+		if w.capture && !w.pre {
+			w.text.WriteString("**")
+		}
+	}
+}
+
+func (w *mdWriter) write(s string) {
+	if w.capture {
+		w.text.WriteString(html.UnescapeString(s))
+	}
+}
+
+// Markdown converts the puzzle articles of a puzzle page to wrapped plain text
+// with markdown emphasis, code, and lists
+func Markdown(page string) string {
+	w := mdWriter{}
+	// ignore everything before the puzzle, such as the site navigation
+	if k := strings.Index(page, "<main>"); k >= 0 {
+		page = page[k:]
+	}
+	last := 0
+	for _, m := range tagRegex.FindAllStringSubmatchIndex(page, -1) {
+		w.write(page[last:m[0]])
+		last = m[1]
+		tag := strings.ToLower(page[m[4]:m[5]])
+		if m[3] > m[2] {
+			w.close(tag)
+		} else {
+			w.open(tag)
+		}
+	}
+	for len(w.lines) > 0 && w.lines[len(w.lines)-1] == "" {
+		w.lines = w.lines[:len(w.lines)-1]
+	}
+	if len(w.lines) == 0 {
+		return ""
+	}
+	return strings.Join(w.lines, "\n") + "\n"
+}
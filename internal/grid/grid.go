@@ -0,0 +1,372 @@
+// Package grid provides 2D grids of cells backed by either a dense slice or a
+// sparse map
+package grid
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/xorkevin/advent2021/internal/input"
+)
+
+var (
+	// ErrRagged is returned when parsing rows of different widths
+	ErrRagged = errors.New("Rows have different widths")
+)
+
+type (
+	// Point is a position on a grid, where y increases downwards
+	Point struct {
+		X, Y int
+	}
+
+	// Grid is a 2D grid of cells
+	Grid[T any] interface {
+		// Get returns the cell at p, or the zero value if it is unset or out of
+		// bounds
+		Get(p Point) T
+		// Set sets the cell at p
+		Set(p Point, v T)
+		// InBounds returns whether p is a cell of the grid
+		InBounds(p Point) bool
+	}
+)
+
+var (
+	// Dirs4 are the offsets to the 4 orthogonal neighbours, in the order left,
+	// up, right, down
+	Dirs4 = [4]Point{{-1, 0}, {0, -1}, {1, 0}, {0, 1}}
+	// Dirs8 are the offsets to the 8 surrounding neighbours, clockwise from the
+	// left
+	Dirs8 = [8]Point{{-1, 0}, {-1, -1}, {0, -1}, {1, -1}, {1, 0}, {1, 1}, {0, 1}, {-1, 1}}
+)
+
+// Add returns the sum of two points
+func (p Point) Add(o Point) Point {
+	return Point{p.X + o.X, p.Y + o.Y}
+}
+
+// Sub returns the difference of two points
+func (p Point) Sub(o Point) Point {
+	return Point{p.X - o.X, p.Y - o.Y}
+}
+
+// Manhattan returns the manhattan distance between two points
+func (p Point) Manhattan(o Point) int {
+	return abs(p.X-o.X) + abs(p.Y-o.Y)
+}
+
+// Wrap returns p wrapped onto a torus of width w and height h
+func (p Point) Wrap(w, h int) Point {
+	return Point{mod(p.X, w), mod(p.Y, h)}
+}
+
+// Neighbors4 returns the 4 orthogonal neighbours of p
+func (p Point) Neighbors4() [4]Point {
+	var k [4]Point
+	for n, i := range Dirs4 {
+		k[n] = p.Add(i)
+	}
+	return k
+}
+
+// Neighbors8 returns the 8 surrounding neighbours of p
+func (p Point) Neighbors8() [8]Point {
+	var k [8]Point
+	for n, i := range Dirs8 {
+		k[n] = p.Add(i)
+	}
+	return k
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func mod(a, b int) int {
+	k := a % b
+	if k < 0 {
+		k += b
+	}
+	return k
+}
+
+// Neighbors4 returns the orthogonal neighbours of p which are in bounds
+func Neighbors4[T any](g Grid[T], p Point) []Point {
+	k := make([]Point, 0, 4)
+	for _, i := range p.Neighbors4() {
+		if g.InBounds(i) {
+			k = append(k, i)
+		}
+	}
+	return k
+}
+
+// Neighbors8 returns the surrounding neighbours of p which are in bounds
+func Neighbors8[T any](g Grid[T], p Point) []Point {
+	k := make([]Point, 0, 8)
+	for _, i := range p.Neighbors8() {
+		if g.InBounds(i) {
+			k = append(k, i)
+		}
+	}
+	return k
+}
+
+// FloodFill returns every cell connected orthogonally to start, including
+// start, for which ok returns true, or nothing if ok rejects start
+func FloodFill[T any](g Grid[T], start Point, ok func(p Point, v T) bool) []Point {
+	if !g.InBounds(start) || !ok(start, g.Get(start)) {
+		return nil
+	}
+	visited := map[Point]struct{}{start: {}}
+	region := []Point{start}
+	stack := []Point{start}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, i := range Neighbors4(g, cur) {
+			if _, seen := visited[i]; seen {
+				continue
+			}
+			visited[i] = struct{}{}
+			if !ok(i, g.Get(i)) {
+				continue
+			}
+			region = append(region, i)
+			stack = append(stack, i)
+		}
+	}
+	return region
+}
+
+type (
+	// Dense is a bounded grid with every cell stored
+	Dense[T any] struct {
+		w, h  int
+		cells []T
+	}
+)
+
+// NewDense creates a grid of width w and height h with every cell set to the
+// zero value
+func NewDense[T any](w, h int) *Dense[T] {
+	return &Dense[T]{
+		w:     w,
+		h:     h,
+		cells: make([]T, w*h),
+	}
+}
+
+// Parse creates a dense grid from rows of text, converting each byte with fn.
+// Errors from fn and rows of differing widths are returned as input.ParseError.
+func Parse[T any](rows [][]byte, expected string, fn func(c byte) (T, error)) (*Dense[T], error) {
+	if len(rows) == 0 {
+		return NewDense[T](0, 0), nil
+	}
+	g := NewDense[T](len(rows[0]), len(rows))
+	for y, row := range rows {
+		if len(row) != g.w {
+			return nil, input.NewParseError(y+1, 0, expected, fmt.Errorf("%w: want %d, got %d", ErrRagged, g.w, len(row)))
+		}
+		for x, c := range row {
+			v, err := fn(c)
+			if err != nil {
+				return nil, input.NewParseError(y+1, x+1, expected, err)
+			}
+			g.cells[y*g.w+x] = v
+		}
+	}
+	return g, nil
+}
+
+// Width returns the number of columns
+func (g *Dense[T]) Width() int {
+	return g.w
+}
+
+// Height returns the number of rows
+func (g *Dense[T]) Height() int {
+	return g.h
+}
+
+// InBounds implements Grid
+func (g *Dense[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.w && p.Y < g.h
+}
+
+// Get implements Grid
+func (g *Dense[T]) Get(p Point) T {
+	if !g.InBounds(p) {
+		var zero T
+		return zero
+	}
+	return g.cells[p.Y*g.w+p.X]
+}
+
+// Set implements Grid, ignoring points out of bounds
+func (g *Dense[T]) Set(p Point, v T) {
+	if !g.InBounds(p) {
+		return
+	}
+	g.cells[p.Y*g.w+p.X] = v
+}
+
+// Wrap returns p wrapped around the edges of the grid
+func (g *Dense[T]) Wrap(p Point) Point {
+	return p.Wrap(g.w, g.h)
+}
+
+// Range calls fn on every cell in row major order
+func (g *Dense[T]) Range(fn func(p Point, v T)) {
+	for y := 0; y < g.h; y++ {
+		for x := 0; x < g.w; x++ {
+			fn(Point{x, y}, g.cells[y*g.w+x])
+		}
+	}
+}
+
+// Clone returns a copy of the grid
+func (g *Dense[T]) Clone() *Dense[T] {
+	cells := make([]T, len(g.cells))
+	copy(cells, g.cells)
+	return &Dense[T]{
+		w:     g.w,
+		h:     g.h,
+		cells: cells,
+	}
+}
+
+// Tile returns a grid of nx by ny copies of g, where each cell of the tile at
+// column tx and row ty is transformed by fn
+func (g *Dense[T]) Tile(nx, ny int, fn func(v T, tx, ty int) T) *Dense[T] {
+	k := NewDense[T](g.w*nx, g.h*ny)
+	for ty := 0; ty < ny; ty++ {
+		for tx := 0; tx < nx; tx++ {
+			g.Range(func(p Point, v T) {
+				k.Set(Point{tx*g.w + p.X, ty*g.h + p.Y}, fn(v, tx, ty))
+			})
+		}
+	}
+	return k
+}
+
+// Render draws each cell as a byte with fn, returning the rows of text
+func (g *Dense[T]) Render(fn func(v T) byte) []string {
+	rows := make([]string, 0, g.h)
+	row := make([]byte, g.w)
+	for y := 0; y < g.h; y++ {
+		for x := 0; x < g.w; x++ {
+			row[x] = fn(g.cells[y*g.w+x])
+		}
+		rows = append(rows, string(row))
+	}
+	return rows
+}
+
+// Sparse copies the cells for which keep returns true into a sparse grid
+func (g *Dense[T]) Sparse(keep func(v T) bool) *Sparse[T] {
+	k := NewSparse[T]()
+	g.Range(func(p Point, v T) {
+		if keep(v) {
+			k.Set(p, v)
+		}
+	})
+	return k
+}
+
+type (
+	// Sparse is an unbounded grid storing only the cells which are set
+	Sparse[T any] struct {
+		cells map[Point]T
+	}
+)
+
+// NewSparse creates an empty sparse grid
+func NewSparse[T any]() *Sparse[T] {
+	return &Sparse[T]{
+		cells: map[Point]T{},
+	}
+}
+
+// InBounds implements Grid, and is true for every point
+func (g *Sparse[T]) InBounds(p Point) bool {
+	return true
+}
+
+// Get implements Grid
+func (g *Sparse[T]) Get(p Point) T {
+	return g.cells[p]
+}
+
+// Has returns whether the cell at p is set
+func (g *Sparse[T]) Has(p Point) bool {
+	_, ok := g.cells[p]
+	return ok
+}
+
+// Set implements Grid
+func (g *Sparse[T]) Set(p Point, v T) {
+	g.cells[p] = v
+}
+
+// Delete unsets the cell at p
+func (g *Sparse[T]) Delete(p Point) {
+	delete(g.cells, p)
+}
+
+// Len returns the number of cells which are set
+func (g *Sparse[T]) Len() int {
+	return len(g.cells)
+}
+
+// Range calls fn on every set cell in no particular order
+func (g *Sparse[T]) Range(fn func(p Point, v T)) {
+	for k, v := range g.cells {
+		fn(k, v)
+	}
+}
+
+// Bounds returns the inclusive min and max corners of the set cells, and
+// false if there are none
+func (g *Sparse[T]) Bounds() (Point, Point, bool) {
+	first := true
+	var min, max Point
+	for i := range g.cells {
+		if first {
+			first = false
+			min, max = i, i
+			continue
+		}
+		if i.X < min.X {
+			min.X = i.X
+		}
+		if i.Y < min.Y {
+			min.Y = i.Y
+		}
+		if i.X > max.X {
+			max.X = i.X
+		}
+		if i.Y > max.Y {
+			max.Y = i.Y
+		}
+	}
+	return min, max, !first
+}
+
+// Dense copies the cells within the bounds of the set cells into a dense grid
+// whose origin is the min corner
+func (g *Sparse[T]) Dense() (*Dense[T], Point) {
+	min, max, ok := g.Bounds()
+	if !ok {
+		return NewDense[T](0, 0), Point{}
+	}
+	k := NewDense[T](max.X-min.X+1, max.Y-min.Y+1)
+	for p, v := range g.cells {
+		k.Set(p.Sub(min), v)
+	}
+	return k, min
+}
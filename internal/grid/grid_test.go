@@ -0,0 +1,109 @@
+package grid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/input"
+)
+
+func parseText(t *testing.T, text string) *Dense[byte] {
+	t.Helper()
+	var rows [][]byte
+	for _, i := range strings.Split(text, "\n") {
+		rows = append(rows, []byte(i))
+	}
+	g, err := Parse(rows, "", func(c byte) (byte, error) {
+		return c, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func identity(c byte) byte {
+	return c
+}
+
+func TestDense(t *testing.T) {
+	g := parseText(t, "ab\ncd")
+	if g.Width() != 2 || g.Height() != 2 {
+		t.Fatalf("unexpected size %dx%d", g.Width(), g.Height())
+	}
+	if v := g.Get(Point{1, 1}); v != 'd' {
+		t.Errorf("want d, got %c", v)
+	}
+	if v := g.Get(Point{2, 0}); v != 0 {
+		t.Errorf("want zero value out of bounds, got %c", v)
+	}
+	if p := g.Wrap(Point{-1, 2}); p != (Point{1, 0}) {
+		t.Errorf("unexpected wrap %v", p)
+	}
+	if n := len(Neighbors4[byte](g, Point{0, 0})); n != 2 {
+		t.Errorf("want 2 corner neighbours, got %d", n)
+	}
+	if n := len(Neighbors8[byte](g, Point{0, 0})); n != 3 {
+		t.Errorf("want 3 corner neighbours, got %d", n)
+	}
+
+	tiled := g.Tile(2, 1, func(v byte, tx, ty int) byte {
+		return v + byte(tx)
+	})
+	if got := strings.Join(tiled.Render(identity), "\n"); got != "abbc\ncdde" {
+		t.Errorf("unexpected tiling %q", got)
+	}
+}
+
+func TestFloodFill(t *testing.T) {
+	g := parseText(t, "..#.\n.##.\n#...")
+	region := FloodFill[byte](g, Point{0, 0}, func(p Point, v byte) bool {
+		return v == '.'
+	})
+	if len(region) != 3 {
+		t.Errorf("want region of 3, got %v", region)
+	}
+	if region := FloodFill[byte](g, Point{2, 0}, func(p Point, v byte) bool {
+		return v == '.'
+	}); region != nil {
+		t.Errorf("want no region from a rejected start, got %v", region)
+	}
+}
+
+func TestSparse(t *testing.T) {
+	g := parseText(t, "#..\n..#").Sparse(func(c byte) bool {
+		return c == '#'
+	})
+	g.Set(Point{-1, 3}, '#')
+	if g.Len() != 3 || !g.Has(Point{2, 1}) || g.Has(Point{1, 1}) {
+		t.Fatalf("unexpected cells")
+	}
+	min, max, ok := g.Bounds()
+	if !ok || min != (Point{-1, 0}) || max != (Point{2, 3}) {
+		t.Errorf("unexpected bounds %v %v", min, max)
+	}
+	d, origin := g.Dense()
+	if origin != min {
+		t.Errorf("want origin %v, got %v", min, origin)
+	}
+	rows := d.Render(func(c byte) byte {
+		if c == 0 {
+			return '.'
+		}
+		return c
+	})
+	if got := strings.Join(rows, "\n"); got != ".#..\n...#\n....\n#..." {
+		t.Errorf("unexpected render %q", got)
+	}
+}
+
+func TestParseRagged(t *testing.T) {
+	_, err := Parse([][]byte{[]byte("ab"), []byte("c")}, "", func(c byte) (byte, error) {
+		return c, nil
+	})
+	var perr *input.ParseError
+	if !errors.As(err, &perr) || perr.Line != 2 || !errors.Is(err, ErrRagged) {
+		t.Errorf("want ragged parse error on line 2, got %v", err)
+	}
+}
@@ -0,0 +1,242 @@
+// Package input reads puzzle inputs from files, stdin, or any io.Reader
+package input
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// DefaultName is the puzzle input used when none is specified
+	DefaultName = "input.txt"
+	// Stdin is the name which refers to standard input
+	Stdin = "-"
+
+	hexFormat = "an even number of hex digits"
+)
+
+var (
+	// ErrInvalidHex is returned when a hex stream has an odd number of digits
+	// or an invalid digit
+	ErrInvalidHex = errors.New("Invalid hex stream")
+)
+
+// Arg returns the input named by the first command line argument, or
+// DefaultName if there is none
+func Arg() string {
+	if len(os.Args) > 1 && os.Args[1] != "" {
+		return os.Args[1]
+	}
+	return DefaultName
+}
+
+type (
+	nopCloser struct {
+		io.Reader
+	}
+)
+
+func (c nopCloser) Close() error {
+	return nil
+}
+
+// Open opens the named input for reading, where Stdin refers to standard
+// input
+func Open(name string) (io.ReadCloser, error) {
+	if name == Stdin {
+		return nopCloser{os.Stdin}, nil
+	}
+	return os.Open(name)
+}
+
+func readFile(name string, fn func(r io.Reader) error) (retErr error) {
+	file, err := Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	return fn(file)
+}
+
+// Lines reads all lines from r
+func Lines(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// LinesFile reads all lines from the named input
+func LinesFile(name string) ([]string, error) {
+	var lines []string
+	if err := readFile(name, func(r io.Reader) error {
+		var err error
+		lines, err = Lines(r)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// Grid reads each line from r as a row of bytes
+func Grid(r io.Reader) ([][]byte, error) {
+	var grid [][]byte
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		grid = append(grid, []byte(scanner.Text()))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return grid, nil
+}
+
+// GridFile reads each line from the named input as a row of bytes
+func GridFile(name string) ([][]byte, error) {
+	var grid [][]byte
+	if err := readFile(name, func(r io.Reader) error {
+		var err error
+		grid, err = Grid(r)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return grid, nil
+}
+
+// Blocks reads groups of lines from r separated by blank lines
+func Blocks(r io.Reader) ([][]string, error) {
+	var blocks [][]string
+	var block []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+// BlocksFile reads groups of lines from the named input separated by blank
+// lines
+func BlocksFile(name string) ([][]string, error) {
+	var blocks [][]string
+	if err := readFile(name, func(r io.Reader) error {
+		var err error
+		blocks, err = Blocks(r)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return blocks, nil
+}
+
+// Ints reads comma separated ints from every line of r
+func Ints(r io.Reader) ([]int, error) {
+	var nums []int
+	scanner := bufio.NewScanner(r)
+	lineno := 0
+	for scanner.Scan() {
+		lineno++
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields, cols := Split(line, ",")
+		for n, i := range fields {
+			k := strings.TrimLeft(i, " \t")
+			num, err := Atoi(strings.TrimSpace(k), lineno, cols[n]+len(i)-len(k), "int[,int...]")
+			if err != nil {
+				return nil, err
+			}
+			nums = append(nums, num)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nums, nil
+}
+
+// IntsFile reads comma separated ints from every line of the named input
+func IntsFile(name string) ([]int, error) {
+	var nums []int
+	if err := readFile(name, func(r io.Reader) error {
+		var err error
+		nums, err = Ints(r)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return nums, nil
+}
+
+// Hex reads a stream of hex digits from r, ignoring whitespace, and returns
+// the decoded bytes
+func Hex(r io.Reader) ([]byte, error) {
+	b := strings.Builder{}
+	scanner := bufio.NewScanner(r)
+	lineno := 0
+	for scanner.Scan() {
+		lineno++
+		line := scanner.Text()
+		for n, i := range []byte(line) {
+			switch {
+			case i == ' ' || i == '\t' || i == '\r':
+			case i >= '0' && i <= '9', i >= 'a' && i <= 'f', i >= 'A' && i <= 'F':
+				b.WriteByte(i)
+			default:
+				return nil, NewParseError(lineno, n+1, hexFormat, ErrInvalidHex)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	k, err := hex.DecodeString(b.String())
+	if err != nil {
+		return nil, NewParseError(lineno, 0, hexFormat, fmt.Errorf("%w: %v", ErrInvalidHex, err))
+	}
+	return k, nil
+}
+
+// HexFile reads a stream of hex digits from the named input, ignoring
+// whitespace, and returns the decoded bytes
+func HexFile(name string) ([]byte, error) {
+	var k []byte
+	if err := readFile(name, func(r io.Reader) error {
+		var err error
+		k, err = Hex(r)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
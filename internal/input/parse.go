@@ -0,0 +1,133 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrParse is matched by every ParseError
+	ErrParse = errors.New("Parse error")
+	// ErrInvalidInt is returned when a field is not a decimal int
+	ErrInvalidInt = errors.New("Invalid int")
+)
+
+type (
+	// ParseError is a malformed line of puzzle input
+	ParseError struct {
+		// File is the name of the input, which is empty until set by a caller
+		// that knows it
+		File string
+		// Line is the 1-indexed line number
+		Line int
+		// Col is the 1-indexed column, or 0 if the whole line is malformed
+		Col int
+		// Expected describes the format of a well formed line
+		Expected string
+		// Err is the underlying cause
+		Err error
+	}
+)
+
+// NewParseError returns a ParseError at the 1-indexed line and column
+func NewParseError(line, col int, expected string, err error) *ParseError {
+	return &ParseError{
+		Line:     line,
+		Col:      col,
+		Expected: expected,
+		Err:      err,
+	}
+}
+
+func (e *ParseError) Error() string {
+	b := strings.Builder{}
+	if e.File != "" {
+		b.WriteString(e.File)
+		b.WriteString(":")
+	}
+	b.WriteString(strconv.Itoa(e.Line))
+	if e.Col > 0 {
+		b.WriteString(":")
+		b.WriteString(strconv.Itoa(e.Col))
+	}
+	b.WriteString(": ")
+	if e.Err != nil {
+		b.WriteString(e.Err.Error())
+	} else {
+		b.WriteString(ErrParse.Error())
+	}
+	if e.Expected != "" {
+		fmt.Fprintf(&b, ", expected %q", e.Expected)
+	}
+	return b.String()
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports that every ParseError matches ErrParse
+func (e *ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// WithFile sets the file name of a ParseError in the chain of err, and returns
+// err
+func WithFile(err error, name string) error {
+	var perr *ParseError
+	if errors.As(err, &perr) && perr.File == "" {
+		perr.File = name
+	}
+	return err
+}
+
+// Atoi parses s, which begins at the 1-indexed line and column, as a decimal
+// int, and returns a ParseError if it is not one
+func Atoi(s string, line, col int, expected string) (int, error) {
+	num, err := strconv.Atoi(s)
+	if err != nil {
+		var nerr *strconv.NumError
+		if errors.As(err, &nerr) {
+			err = nerr.Err
+		}
+		return 0, NewParseError(line, col, expected, fmt.Errorf("%w %q: %v", ErrInvalidInt, s, err))
+	}
+	return num, nil
+}
+
+// Split splits s by sep like strings.Split, returning each field with its
+// 1-indexed column
+func Split(s, sep string) ([]string, []int) {
+	fields := strings.Split(s, sep)
+	cols := make([]int, len(fields))
+	col := 1
+	for n, i := range fields {
+		cols[n] = col
+		col += len(i) + len(sep)
+	}
+	return fields, cols
+}
+
+// Fields splits s around runs of spaces and tabs like strings.Fields, returning
+// each field with its 1-indexed column
+func Fields(s string) ([]string, []int) {
+	var fields []string
+	var cols []int
+	start := -1
+	for n := 0; n <= len(s); n++ {
+		if n < len(s) && s[n] != ' ' && s[n] != '\t' {
+			if start < 0 {
+				start = n
+			}
+			continue
+		}
+		if start >= 0 {
+			fields = append(fields, s[start:n])
+			cols = append(cols, start+1)
+			start = -1
+		}
+	}
+	return fields, cols
+}
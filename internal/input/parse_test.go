@@ -0,0 +1,65 @@
+package input
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		Name  string
+		Err   error
+		Want  string
+		Cause error
+	}{
+		{
+			Name:  "ints",
+			Err:   errOf(Ints(strings.NewReader("1,2\n3, x\n"))),
+			Want:  `input.txt:2:4: Invalid int "x": invalid syntax, expected "int[,int...]"`,
+			Cause: ErrInvalidInt,
+		},
+		{
+			Name:  "hex",
+			Err:   errOf(Hex(strings.NewReader("8A00\n4AG0\n"))),
+			Want:  `input.txt:2:3: Invalid hex stream, expected "an even number of hex digits"`,
+			Cause: ErrInvalidHex,
+		},
+		{
+			Name:  "odd hex",
+			Err:   errOf(Hex(strings.NewReader("8A0\n"))),
+			Want:  `input.txt:1: Invalid hex stream: encoding/hex: odd length hex string, expected "an even number of hex digits"`,
+			Cause: ErrInvalidHex,
+		},
+	} {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			err := WithFile(tc.Err, DefaultName)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !errors.Is(err, ErrParse) || !errors.Is(err, tc.Cause) {
+				t.Errorf("error %v does not match ErrParse and %v", err, tc.Cause)
+			}
+			if got := err.Error(); got != tc.Want {
+				t.Errorf("want %q, got %q", tc.Want, got)
+			}
+		})
+	}
+}
+
+func TestFields(t *testing.T) {
+	fields, cols := Fields("  add x\t-12 ")
+	if strings.Join(fields, "|") != "add|x|-12" {
+		t.Errorf("unexpected fields %q", fields)
+	}
+	for n, want := range []int{3, 7, 9} {
+		if cols[n] != want {
+			t.Errorf("field %d: want column %d, got %d", n, want, cols[n])
+		}
+	}
+}
+
+func errOf(_ interface{}, err error) error {
+	return err
+}
@@ -0,0 +1,110 @@
+// Package measure records the time and memory used by a single run of a
+// function
+package measure
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"time"
+)
+
+const (
+	heapMetric = "/memory/classes/heap/objects:bytes"
+	// sampleInterval is how often the live heap is sampled while a function
+	// runs, to find its peak across garbage collections
+	sampleInterval = time.Millisecond
+)
+
+type (
+	// Sample is the resources used by a run. Memory is measured for the whole
+	// process, so runs should not overlap.
+	Sample struct {
+		Elapsed time.Duration
+		// Allocs is the number of heap objects allocated
+		Allocs uint64
+		// AllocBytes is the total size of heap objects allocated
+		AllocBytes uint64
+		// PeakHeap is the largest size of the live heap above what was live
+		// before the run. It is exact if no garbage collection happened during
+		// the run, and is otherwise sampled.
+		PeakHeap uint64
+	}
+
+	heapSampler struct {
+		peak uint64
+		stop chan struct{}
+		done chan struct{}
+	}
+)
+
+func readHeap(s []metrics.Sample) uint64 {
+	metrics.Read(s)
+	if s[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return s[0].Value.Uint64()
+}
+
+func startSampler() *heapSampler {
+	h := &heapSampler{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer close(h.done)
+		s := []metrics.Sample{{Name: heapMetric}}
+		ticker := time.NewTicker(sampleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.stop:
+				return
+			case <-ticker.C:
+				if v := readHeap(s); v > h.peak {
+					h.peak = v
+				}
+			}
+		}
+	}()
+	return h
+}
+
+// Stop stops sampling and returns the largest sample
+func (h *heapSampler) Stop() uint64 {
+	close(h.stop)
+	<-h.done
+	return h.peak
+}
+
+// Run runs fn once after a garbage collection, and returns the resources it
+// used
+func Run(fn func() error) (Sample, error) {
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+	sampler := startSampler()
+
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start)
+
+	sampled := sampler.Stop()
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+
+	peak := after.HeapAlloc
+	if after.NumGC != before.NumGC && sampled > peak {
+		peak = sampled
+	}
+	if peak > before.HeapAlloc {
+		peak -= before.HeapAlloc
+	} else {
+		peak = 0
+	}
+	return Sample{
+		Elapsed:    elapsed,
+		Allocs:     after.Mallocs - before.Mallocs,
+		AllocBytes: after.TotalAlloc - before.TotalAlloc,
+		PeakHeap:   peak,
+	}, err
+}
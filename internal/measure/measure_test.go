@@ -0,0 +1,41 @@
+package measure
+
+import (
+	"errors"
+	"testing"
+)
+
+var sink [][]byte
+
+func TestRun(t *testing.T) {
+	const n = 64
+	const size = 1 << 16
+	s, err := Run(func() error {
+		sink = make([][]byte, 0, n)
+		for i := 0; i < n; i++ {
+			sink = append(sink, make([]byte, size))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Elapsed <= 0 {
+		t.Errorf("want positive elapsed time, got %v", s.Elapsed)
+	}
+	if s.Allocs < n {
+		t.Errorf("want at least %d allocs, got %d", n, s.Allocs)
+	}
+	if s.AllocBytes < n*size {
+		t.Errorf("want at least %d bytes allocated, got %d", n*size, s.AllocBytes)
+	}
+	if s.PeakHeap < n*size {
+		t.Errorf("want peak heap at least %d, got %d", n*size, s.PeakHeap)
+	}
+	sink = nil
+
+	errTest := errors.New("test")
+	if _, err := Run(func() error { return errTest }); !errors.Is(err, errTest) {
+		t.Errorf("want error returned, got %v", err)
+	}
+}
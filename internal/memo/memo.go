@@ -0,0 +1,107 @@
+// Package memo caches the results of pure functions
+package memo
+
+import (
+	"container/list"
+	"sync"
+)
+
+type (
+	// Memo is a cache of values by key which is safe for concurrent use. A
+	// bounded memo evicts its least recently used value when full.
+	Memo[K comparable, V any] struct {
+		mu    sync.Mutex
+		max   int
+		items map[K]*list.Element
+		// order holds the entries from most to least recently used, and is
+		// only kept when the memo is bounded
+		order *list.List
+	}
+
+	entry[K comparable, V any] struct {
+		key   K
+		value V
+	}
+)
+
+// New creates a memo holding at most max values, or any number of values if
+// max is less than 1
+func New[K comparable, V any](max int) *Memo[K, V] {
+	m := &Memo[K, V]{
+		max:   max,
+		items: map[K]*list.Element{},
+	}
+	if max > 0 {
+		m.order = list.New()
+	}
+	return m
+}
+
+// Get returns the value of k, and whether it is present
+func (m *Memo[K, V]) Get(k K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.items[k]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	if m.order != nil {
+		m.order.MoveToFront(e)
+	}
+	return e.Value.(*entry[K, V]).value, true
+}
+
+// Set sets the value of k, evicting the least recently used value if the memo
+// is full
+func (m *Memo[K, V]) Set(k K, v V) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if e, ok := m.items[k]; ok {
+		e.Value.(*entry[K, V]).value = v
+		if m.order != nil {
+			m.order.MoveToFront(e)
+		}
+		return
+	}
+	ent := &entry[K, V]{key: k, value: v}
+	if m.order == nil {
+		m.items[k] = &list.Element{Value: ent}
+		return
+	}
+	if m.order.Len() >= m.max {
+		last := m.order.Back()
+		m.order.Remove(last)
+		delete(m.items, last.Value.(*entry[K, V]).key)
+	}
+	m.items[k] = m.order.PushFront(ent)
+}
+
+// Do returns the value of k, computing it with fn and storing it if it is
+// absent. The lock is not held while fn runs, so fn may itself call Do, and
+// concurrent calls for the same absent key may each run fn.
+func (m *Memo[K, V]) Do(k K, fn func() V) V {
+	if v, ok := m.Get(k); ok {
+		return v
+	}
+	v := fn()
+	m.Set(k, v)
+	return v
+}
+
+// Len returns the number of values held
+func (m *Memo[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.items)
+}
+
+// Reset removes every value
+func (m *Memo[K, V]) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items = map[K]*list.Element{}
+	if m.order != nil {
+		m.order.Init()
+	}
+}
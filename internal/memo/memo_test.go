@@ -0,0 +1,89 @@
+package memo
+
+import (
+	"sync"
+	"testing"
+)
+
+func fib(m *Memo[int, int], n int) int {
+	if n < 2 {
+		return n
+	}
+	return m.Do(n, func() int {
+		return fib(m, n-1) + fib(m, n-2)
+	})
+}
+
+func TestMemo(t *testing.T) {
+	m := New[int, int](0)
+	if v := fib(m, 90); v != 2880067194370816120 {
+		t.Errorf("want fib 90, got %d", v)
+	}
+	if m.Len() != 89 {
+		t.Errorf("want 89 values, got %d", m.Len())
+	}
+	calls := 0
+	if v := m.Do(90, func() int { calls++; return 0 }); v != 2880067194370816120 || calls != 0 {
+		t.Errorf("want cached value without calling fn, got %d after %d calls", v, calls)
+	}
+	m.Reset()
+	if m.Len() != 0 {
+		t.Errorf("want empty after reset, got %d", m.Len())
+	}
+	if _, ok := m.Get(90); ok {
+		t.Error("want value removed by reset")
+	}
+}
+
+func TestMemoBounded(t *testing.T) {
+	m := New[string, int](2)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	// a is now more recently used than b
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("want a 1, got %d %v", v, ok)
+	}
+	m.Set("c", 3)
+	if m.Len() != 2 {
+		t.Errorf("want 2 values, got %d", m.Len())
+	}
+	if _, ok := m.Get("b"); ok {
+		t.Error("want least recently used b evicted")
+	}
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Errorf("want a kept, got %d %v", v, ok)
+	}
+	m.Set("a", 4)
+	if v, _ := m.Get("a"); v != 4 {
+		t.Errorf("want a updated to 4, got %d", v)
+	}
+	m.Reset()
+	m.Set("d", 5)
+	if m.Len() != 1 {
+		t.Errorf("want 1 value after reset, got %d", m.Len())
+	}
+
+	// recursion still computes correct values when entries are evicted
+	f := New[int, int](4)
+	if v := fib(f, 40); v != 102334155 {
+		t.Errorf("want fib 40, got %d", v)
+	}
+}
+
+func TestMemoConcurrent(t *testing.T) {
+	m := New[int, int](16)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				k := j % 32
+				if v := m.Do(k, func() int { return k * k }); v != k*k {
+					t.Errorf("want %d, got %d", k*k, v)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,296 @@
+// Package parity checks that the Go and Rust solutions give the same answers
+package parity
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+	"github.com/xorkevin/advent2021/internal/input"
+)
+
+var (
+	ErrNoPart = errors.New("Output has no part answers")
+)
+
+var (
+	partLine = regexp.MustCompile(`^(?:day\d+ )?[Pp]art ([12]):\s?(.*)$`)
+)
+
+type (
+	// Answers maps a part number to its printed answer
+	Answers map[int]string
+
+	// Program runs a solution on an input file
+	Program interface {
+		Lang() string
+		Run(ctx context.Context, day int, name string) (Answers, error)
+	}
+
+	// Go runs the advent binary
+	Go struct {
+		Bin string
+	}
+
+	// Rust runs the release binary of each day's crate, which reads input.txt
+	// from its working directory
+	Rust struct {
+		// TargetDir is the cargo target directory, or empty for each crate's own
+		// target directory
+		TargetDir string
+		Root      string
+	}
+
+	// Mismatch is a part whose answers differ between the two programs
+	Mismatch struct {
+		Day   int
+		Input string
+		Part  int
+		Go    string
+		Rust  string
+	}
+
+	// Failure is an input that either program failed to run on
+	Failure struct {
+		Day   int
+		Input string
+		Lang  string
+		Err   error
+	}
+)
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%s/%s part %d: go %q, rust %q", answers.DirName(m.Day), m.Input, m.Part, m.Go, m.Rust)
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("%s/%s %s: %v", answers.DirName(f.Day), f.Input, f.Lang, f.Err)
+}
+
+// ParseOutput reads the answers from "Part 1:" and "Part 2:" lines, where an
+// answer that spans multiple lines begins on the line after its label. Go's
+// "dayNN part 1:" labels are accepted as well. Trailing whitespace is removed
+// from every line.
+func ParseOutput(r io.Reader) (Answers, error) {
+	res := Answers{}
+	part := 0
+	var lines []string
+	flush := func() {
+		if part != 0 {
+			res[part] = strings.Join(lines, "\n")
+		}
+	}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if m := partLine.FindStringSubmatch(line); m != nil {
+			flush()
+			part, _ = strconv.Atoi(m[1])
+			lines = nil
+			if m[2] != "" {
+				lines = append(lines, m[2])
+			}
+			continue
+		}
+		if part != 0 {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	if len(res) == 0 {
+		return nil, ErrNoPart
+	}
+	return res, nil
+}
+
+func runCmd(cmd *exec.Cmd) (Answers, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+	return ParseOutput(&stdout)
+}
+
+func (g Go) Lang() string {
+	return "go"
+}
+
+// Run runs "advent run" on the day
+func (g Go) Run(ctx context.Context, day int, name string) (Answers, error) {
+	return runCmd(exec.CommandContext(ctx, g.Bin, "run", strconv.Itoa(day), "--input", name))
+}
+
+func (r Rust) Lang() string {
+	return "rs"
+}
+
+// Bin returns the path to the release binary of the day
+func (r Rust) Bin(day int) string {
+	dir := r.TargetDir
+	if dir == "" {
+		dir = filepath.Join(r.Root, answers.DirName(day), "target")
+	}
+	return filepath.Join(dir, "release", answers.DirName(day))
+}
+
+// Run copies the input to input.txt in a temporary directory, and runs the
+// day's binary there
+func (r Rust) Run(ctx context.Context, day int, name string) (_ Answers, retErr error) {
+	bin, err := filepath.Abs(r.Bin(day))
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "adventparity")
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	if err := copyFile(filepath.Join(dir, input.DefaultName), name); err != nil {
+		return nil, err
+	}
+	cmd := exec.CommandContext(ctx, bin)
+	cmd.Dir = dir
+	return runCmd(cmd)
+}
+
+// Build builds the crate of the day in release mode
+func (r Rust) Build(ctx context.Context, day int) error {
+	args := []string{"build", "--release", "--quiet"}
+	if r.TargetDir != "" {
+		dir, err := filepath.Abs(r.TargetDir)
+		if err != nil {
+			return err
+		}
+		args = append(args, "--target-dir", dir)
+	}
+	cmd := exec.CommandContext(ctx, "cargo", args...)
+	cmd.Dir = filepath.Join(r.Root, answers.DirName(day))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("cargo build %s: %w: %s", answers.DirName(day), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// BuildGo builds the advent binary into bin
+func BuildGo(ctx context.Context, root, bin string) error {
+	out, err := filepath.Abs(bin)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "go", "build", "-o", out, "./cmd/advent")
+	cmd.Dir = root
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("go build: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func copyFile(dst, src string) error {
+	b, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dst, b, 0644)
+}
+
+// RustDays returns the days which have a Rust crate
+func RustDays(root string) ([]int, error) {
+	matches, err := filepath.Glob(filepath.Join(root, "day*", "Cargo.toml"))
+	if err != nil {
+		return nil, err
+	}
+	var days []int
+	for _, i := range matches {
+		day, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(filepath.Dir(i)), "day"))
+		if err != nil {
+			continue
+		}
+		days = append(days, day)
+	}
+	sort.Ints(days)
+	return days, nil
+}
+
+// Inputs returns the bundled input files of the day
+func Inputs(root string, day int) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(root, answers.DirName(day), "input*.txt"))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(matches))
+	for _, i := range matches {
+		names = append(names, filepath.Base(i))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Compare runs both programs on every bundled input of the day, and returns
+// the parts whose answers differ and the inputs that either program failed on
+func Compare(ctx context.Context, goProg, rsProg Program, root string, day int) ([]Mismatch, []Failure, error) {
+	names, err := Inputs(root, day)
+	if err != nil {
+		return nil, nil, err
+	}
+	var mismatches []Mismatch
+	var failures []Failure
+	for _, i := range names {
+		name := filepath.Join(root, answers.DirName(day), i)
+		results := make([]Answers, 0, 2)
+		for _, p := range []Program{goProg, rsProg} {
+			a, err := p.Run(ctx, day, name)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, nil, ctx.Err()
+				}
+				failures = append(failures, Failure{
+					Day:   day,
+					Input: i,
+					Lang:  p.Lang(),
+					Err:   err,
+				})
+				break
+			}
+			results = append(results, a)
+		}
+		if len(results) != 2 {
+			continue
+		}
+		for part := 1; part <= 2; part++ {
+			g, r := results[0][part], results[1][part]
+			if g != r {
+				mismatches = append(mismatches, Mismatch{
+					Day:   day,
+					Input: i,
+					Part:  part,
+					Go:    g,
+					Rust:  r,
+				})
+			}
+		}
+	}
+	return mismatches, failures, nil
+}
@@ -0,0 +1,96 @@
+package parity
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+)
+
+// parityEnv enables TestParity, which builds every solution and may need to
+// download dependencies
+const parityEnv = "ADVENT_PARITY"
+
+func TestParseOutput(t *testing.T) {
+	for _, tc := range []struct {
+		Name string
+		Text string
+	}{
+		{
+			Name: "rust",
+			Text: "Part 1: 17\nPart 2:\n#  # \n####\n",
+		},
+		{
+			Name: "go",
+			Text: "day13 part 1: 17\nday13 part 2:\n#  #\n####\n",
+		},
+	} {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			a, err := ParseOutput(strings.NewReader(tc.Text))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if a[1] != "17" {
+				t.Errorf("Part 1: want %q, got %q", "17", a[1])
+			}
+			if want := "#  #\n####"; a[2] != want {
+				t.Errorf("Part 2: want %q, got %q", want, a[2])
+			}
+		})
+	}
+
+	if _, err := ParseOutput(strings.NewReader("no answers\n")); err == nil {
+		t.Error("expected error for output without parts")
+	}
+}
+
+func TestParity(t *testing.T) {
+	if os.Getenv(parityEnv) == "" {
+		t.Skipf("set $%s to check parity", parityEnv)
+	}
+	for _, i := range []string{"go", "cargo"} {
+		if _, err := exec.LookPath(i); err != nil {
+			t.Skipf("%s not available", i)
+		}
+	}
+
+	const root = "../.."
+	ctx := context.Background()
+	tmp := t.TempDir()
+	goProg := Go{Bin: filepath.Join(tmp, "advent")}
+	if err := BuildGo(ctx, root, goProg.Bin); err != nil {
+		t.Skipf("cannot build the go solutions: %v", err)
+	}
+	rsProg := Rust{
+		TargetDir: filepath.Join(tmp, "target"),
+		Root:      root,
+	}
+
+	days, err := RustDays(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range days {
+		day := i
+		t.Run(answers.DirName(day), func(t *testing.T) {
+			if err := rsProg.Build(ctx, day); err != nil {
+				t.Skipf("cannot build the rust solution: %v", err)
+			}
+			mismatches, failures, err := Compare(ctx, goProg, rsProg, root, day)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, j := range failures {
+				t.Error(j)
+			}
+			for _, j := range mismatches {
+				t.Error(j)
+			}
+		})
+	}
+}
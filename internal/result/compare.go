@@ -0,0 +1,208 @@
+package result
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidKind = errors.New("Invalid answer kind")
+)
+
+type (
+	// Comparison is a part's result in a baseline run and the current run
+	Comparison struct {
+		Day      int
+		Part     int
+		Baseline Result
+		Current  Result
+	}
+
+	resultKey struct {
+		day, part int
+	}
+
+	readResult struct {
+		jsonResult
+		Answer json.RawMessage `json:"answer"`
+	}
+)
+
+func decodeAnswer(kind string, raw json.RawMessage) (solver.Answer, error) {
+	switch kind {
+	case "":
+		return solver.Answer{}, nil
+	case "int":
+		var v int
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return solver.Answer{}, err
+		}
+		return solver.Int(v), nil
+	case "string":
+		var v string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return solver.Answer{}, err
+		}
+		return solver.String(v), nil
+	case "grid":
+		var v []string
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return solver.Answer{}, err
+		}
+		return solver.Grid(v), nil
+	default:
+		return solver.Answer{}, fmt.Errorf("%w: %s", ErrInvalidKind, kind)
+	}
+}
+
+// Read reads results written in the json format
+func Read(r io.Reader) ([]Result, error) {
+	var results []Result
+	dec := json.NewDecoder(r)
+	for {
+		var v readResult
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return results, nil
+			}
+			return nil, err
+		}
+		a, err := decodeAnswer(v.Kind, v.Answer)
+		if err != nil {
+			return nil, fmt.Errorf("%s part %d: %w", answers.DirName(v.Day), v.Part, err)
+		}
+		results = append(results, Result{
+			Day:        v.Day,
+			Part:       v.Part,
+			Answer:     a,
+			InputHash:  v.InputHash,
+			Elapsed:    time.Duration(v.ElapsedNS),
+			Allocs:     v.Allocs,
+			AllocBytes: v.AllocBytes,
+			PeakHeap:   v.PeakHeap,
+			Measured:   v.Measured,
+		})
+	}
+}
+
+// Load reads results in the json format from the named file
+func Load(name string) (_ []Result, retErr error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	return Read(file)
+}
+
+// Compare pairs each current result with the baseline result of the same part
+func Compare(baseline, current []Result) []Comparison {
+	base := map[resultKey]Result{}
+	for _, i := range baseline {
+		base[resultKey{i.Day, i.Part}] = i
+	}
+	var comps []Comparison
+	for _, i := range current {
+		b, ok := base[resultKey{i.Day, i.Part}]
+		if !ok {
+			continue
+		}
+		comps = append(comps, Comparison{
+			Day:      i.Day,
+			Part:     i.Part,
+			Baseline: b,
+			Current:  i,
+		})
+	}
+	return comps
+}
+
+func ratio(current, baseline float64) float64 {
+	if baseline == 0 {
+		return 0
+	}
+	return current / baseline
+}
+
+// SameInput returns whether both runs solved the same input
+func (c Comparison) SameInput() bool {
+	return c.Baseline.InputHash == c.Current.InputHash
+}
+
+// SameAnswer returns whether both runs gave the same answer
+func (c Comparison) SameAnswer() bool {
+	return c.Baseline.Answer.String() == c.Current.Answer.String()
+}
+
+// TimeRatio is the current elapsed time divided by the baseline
+func (c Comparison) TimeRatio() float64 {
+	return ratio(float64(c.Current.Elapsed), float64(c.Baseline.Elapsed))
+}
+
+// Measured returns whether memory was measured in both runs
+func (c Comparison) Measured() bool {
+	return c.Baseline.Measured && c.Current.Measured
+}
+
+// AllocRatio is the current bytes allocated divided by the baseline
+func (c Comparison) AllocRatio() float64 {
+	return ratio(float64(c.Current.AllocBytes), float64(c.Baseline.AllocBytes))
+}
+
+// HeapRatio is the current peak heap divided by the baseline
+func (c Comparison) HeapRatio() float64 {
+	return ratio(float64(c.Current.PeakHeap), float64(c.Baseline.PeakHeap))
+}
+
+func formatRatio(v float64) string {
+	return strconv.FormatFloat(v, 'f', 3, 64)
+}
+
+// notMeasured replaces the memory ratios of a comparison unless memory was
+// measured in both runs
+const notMeasured = "not measured"
+
+// WriteComparisons writes a table of the time, allocation, and peak heap
+// ratios of each comparison, noting parts whose input or answer changed
+func WriteComparisons(w io.Writer, comps []Comparison) error {
+	const format = "%-8s %-6s %16s %16s %10s %12s %12s"
+	if _, err := fmt.Fprintf(w, format+"\n", "day", "part", "baseline", "elapsed", "time", "alloc", "heap"); err != nil {
+		return err
+	}
+	for _, i := range comps {
+		alloc, heap := notMeasured, notMeasured
+		if i.Measured() {
+			alloc, heap = formatRatio(i.AllocRatio()), formatRatio(i.HeapRatio())
+		}
+		line := fmt.Sprintf(format,
+			answers.DirName(i.Day),
+			strconv.Itoa(i.Part),
+			i.Baseline.Elapsed.String(),
+			i.Current.Elapsed.String(),
+			formatRatio(i.TimeRatio()),
+			alloc,
+			heap,
+		)
+		if !i.SameInput() {
+			line += "  input changed"
+		} else if !i.SameAnswer() {
+			line += "  answer changed"
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package result
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+func TestReadCompare(t *testing.T) {
+	baseline := []Result{
+		{
+			Day:        6,
+			Part:       1,
+			Answer:     solver.Int(5934),
+			InputHash:  "abc",
+			Elapsed:    2 * time.Millisecond,
+			Allocs:     10,
+			AllocBytes: 1000,
+			PeakHeap:   400,
+			Measured:   true,
+		},
+		{
+			Day:       6,
+			Part:      2,
+			Answer:    solver.String("x"),
+			InputHash: "abc",
+			Elapsed:   time.Millisecond,
+		},
+		{
+			Day:       13,
+			Part:      2,
+			Answer:    solver.Grid([]string{"# #", " # "}),
+			InputHash: "def",
+			Elapsed:   time.Millisecond,
+		},
+	}
+	var b bytes.Buffer
+	w, err := NewWriter(&b, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range baseline {
+		if err := w.Write(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read, err := Read(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(read) != len(baseline) {
+		t.Fatalf("want %d results, got %d", len(baseline), len(read))
+	}
+	for n, i := range read {
+		if i.Answer.String() != baseline[n].Answer.String() || i.Answer.Kind() != baseline[n].Answer.Kind() {
+			t.Errorf("want answer %q, got %q", baseline[n].Answer, i.Answer)
+		}
+		i.Answer = baseline[n].Answer
+		if i.Elapsed != baseline[n].Elapsed || i.PeakHeap != baseline[n].PeakHeap || i.Measured != baseline[n].Measured || i.InputHash != baseline[n].InputHash {
+			t.Errorf("want %+v, got %+v", baseline[n], i)
+		}
+	}
+
+	current := []Result{
+		{
+			Day:        6,
+			Part:       1,
+			Answer:     solver.Int(5934),
+			InputHash:  "abc",
+			Elapsed:    time.Millisecond,
+			Allocs:     10,
+			AllocBytes: 500,
+			PeakHeap:   800,
+			Measured:   true,
+		},
+		{
+			Day:       6,
+			Part:      2,
+			Answer:    solver.String("y"),
+			InputHash: "abc",
+			Elapsed:   time.Millisecond,
+		},
+		{
+			Day:       13,
+			Part:      2,
+			Answer:    solver.Grid([]string{"# #", " # "}),
+			InputHash: "xyz",
+			Elapsed:   time.Millisecond,
+		},
+		{
+			Day:     14,
+			Part:    1,
+			Answer:  solver.Int(1),
+			Elapsed: time.Millisecond,
+		},
+	}
+	comps := Compare(read, current)
+	if len(comps) != 3 {
+		t.Fatalf("want 3 comparisons, got %d", len(comps))
+	}
+	c := comps[0]
+	if c.TimeRatio() != 0.5 || c.AllocRatio() != 0.5 || c.HeapRatio() != 2 {
+		t.Errorf("unexpected ratios %v %v %v", c.TimeRatio(), c.AllocRatio(), c.HeapRatio())
+	}
+	if !c.SameInput() || !c.SameAnswer() {
+		t.Error("want same input and answer")
+	}
+	if !c.Measured() || comps[1].Measured() {
+		t.Error("want memory measured only for day 6 part 1")
+	}
+	if comps[1].SameAnswer() {
+		t.Error("want changed answer")
+	}
+	if comps[2].SameInput() {
+		t.Error("want changed input")
+	}
+
+	var out bytes.Buffer
+	if err := WriteComparisons(&out, comps); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("want 4 lines, got:\n%s", out.String())
+	}
+	if !strings.HasSuffix(lines[2], "answer changed") || !strings.HasSuffix(lines[3], "input changed") {
+		t.Errorf("changes not noted:\n%s", out.String())
+	}
+	if strings.Contains(lines[1], notMeasured) || !strings.Contains(lines[2], notMeasured) {
+		t.Errorf("unmeasured memory not noted:\n%s", out.String())
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	if _, err := Read(strings.NewReader(`{"day":1,"part":1,"kind":"float","answer":1.5}`)); err == nil {
+		t.Error("want error for unknown kind")
+	}
+	if _, err := Read(strings.NewReader(`{"day":1`)); err == nil {
+		t.Error("want error for truncated json")
+	}
+}
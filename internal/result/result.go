@@ -0,0 +1,157 @@
+// Package result formats solver answers for people and for other programs
+package result
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrInvalidFormat = errors.New("Invalid format")
+)
+
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+	FormatTSV  = "tsv"
+)
+
+type (
+	// Result is the answer to one part of a day's puzzle
+	Result struct {
+		Day       int
+		Part      int
+		Answer    solver.Answer
+		InputHash string
+		Elapsed   time.Duration
+		// Allocs is the number of heap objects allocated while solving
+		Allocs uint64
+		// AllocBytes is the total size of heap objects allocated while solving
+		AllocBytes uint64
+		// PeakHeap is the largest size of the live heap above what was live
+		// before solving
+		PeakHeap uint64
+		// Measured is whether memory was measured. Allocs, AllocBytes, and
+		// PeakHeap are 0 if it was not, as when days are solved concurrently.
+		Measured bool
+	}
+
+	// Writer writes results in a particular format
+	Writer interface {
+		Write(r Result) error
+	}
+
+	textWriter struct {
+		w io.Writer
+	}
+
+	jsonWriter struct {
+		enc *json.Encoder
+	}
+
+	tsvWriter struct {
+		w      io.Writer
+		header bool
+	}
+
+	jsonResult struct {
+		Day        int           `json:"day"`
+		Part       int           `json:"part"`
+		Kind       string        `json:"kind"`
+		Answer     solver.Answer `json:"answer"`
+		InputHash  string        `json:"input_hash"`
+		ElapsedNS  int64         `json:"elapsed_ns"`
+		Allocs     uint64        `json:"allocs"`
+		AllocBytes uint64        `json:"alloc_bytes"`
+		PeakHeap   uint64        `json:"peak_heap_bytes"`
+		Measured   bool          `json:"measured"`
+	}
+)
+
+// HashInput returns the hex encoded sha256 hash of a puzzle input
+func HashInput(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+// NewWriter returns a writer for the named format, which is one of text, json,
+// or tsv
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatText:
+		return &textWriter{w: w}, nil
+	case FormatJSON:
+		return &jsonWriter{enc: json.NewEncoder(w)}, nil
+	case FormatTSV:
+		return &tsvWriter{w: w}, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidFormat, format)
+	}
+}
+
+// Write prints the answer after a "dayNN part N:" label, or on the lines after
+// the label if the answer spans multiple lines
+func (w *textWriter) Write(r Result) error {
+	s := r.Answer.String()
+	if strings.Contains(s, "\n") {
+		_, err := fmt.Fprintf(w.w, "%s part %d:\n%s\n", answers.DirName(r.Day), r.Part, s)
+		return err
+	}
+	_, err := fmt.Fprintf(w.w, "%s part %d: %s\n", answers.DirName(r.Day), r.Part, s)
+	return err
+}
+
+// Write writes the result as a single line JSON object
+func (w *jsonWriter) Write(r Result) error {
+	return w.enc.Encode(jsonResult{
+		Day:        r.Day,
+		Part:       r.Part,
+		Kind:       r.Answer.Kind(),
+		Answer:     r.Answer,
+		InputHash:  r.InputHash,
+		ElapsedNS:  r.Elapsed.Nanoseconds(),
+		Allocs:     r.Allocs,
+		AllocBytes: r.AllocBytes,
+		PeakHeap:   r.PeakHeap,
+		Measured:   r.Measured,
+	})
+}
+
+var (
+	tsvEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+)
+
+// Write writes the result as a tab separated row, preceded by a header row on
+// the first call, where multi-line answers have their newlines escaped
+func (w *tsvWriter) Write(r Result) error {
+	if !w.header {
+		w.header = true
+		if _, err := io.WriteString(w.w, "day\tpart\tkind\tanswer\tinput_hash\telapsed_ns\tallocs\talloc_bytes\tpeak_heap_bytes\tmeasured\n"); err != nil {
+			return err
+		}
+	}
+	row := []string{
+		strconv.Itoa(r.Day),
+		strconv.Itoa(r.Part),
+		r.Answer.Kind(),
+		tsvEscaper.Replace(r.Answer.String()),
+		r.InputHash,
+		strconv.FormatInt(r.Elapsed.Nanoseconds(), 10),
+		strconv.FormatUint(r.Allocs, 10),
+		strconv.FormatUint(r.AllocBytes, 10),
+		strconv.FormatUint(r.PeakHeap, 10),
+		strconv.FormatBool(r.Measured),
+	}
+	_, err := io.WriteString(w.w, strings.Join(row, "\t")+"\n")
+	return err
+}
@@ -0,0 +1,73 @@
+package result
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+func TestWriter(t *testing.T) {
+	results := []Result{
+		{
+			Day:        13,
+			Part:       1,
+			Answer:     solver.Int(17),
+			InputHash:  "abc",
+			Elapsed:    time.Microsecond,
+			Allocs:     3,
+			AllocBytes: 128,
+			PeakHeap:   64,
+			Measured:   true,
+		},
+		{
+			Day:       13,
+			Part:      2,
+			Answer:    solver.Grid([]string{"# #", " # "}),
+			InputHash: "abc",
+			Elapsed:   2 * time.Microsecond,
+		},
+	}
+	for _, tc := range []struct {
+		Format string
+		Want   string
+	}{
+		{
+			Format: FormatText,
+			Want:   "day13 part 1: 17\nday13 part 2:\n# #\n # \n",
+		},
+		{
+			Format: FormatJSON,
+			Want: `{"day":13,"part":1,"kind":"int","answer":17,"input_hash":"abc","elapsed_ns":1000,"allocs":3,"alloc_bytes":128,"peak_heap_bytes":64,"measured":true}` + "\n" +
+				`{"day":13,"part":2,"kind":"grid","answer":["# #"," # "],"input_hash":"abc","elapsed_ns":2000,"allocs":0,"alloc_bytes":0,"peak_heap_bytes":0,"measured":false}` + "\n",
+		},
+		{
+			Format: FormatTSV,
+			Want: "day\tpart\tkind\tanswer\tinput_hash\telapsed_ns\tallocs\talloc_bytes\tpeak_heap_bytes\tmeasured\n" +
+				"13\t1\tint\t17\tabc\t1000\t3\t128\t64\ttrue\n" +
+				"13\t2\tgrid\t# #\\n # \tabc\t2000\t0\t0\t0\tfalse\n",
+		},
+	} {
+		tc := tc
+		t.Run(tc.Format, func(t *testing.T) {
+			var b bytes.Buffer
+			w, err := NewWriter(&b, tc.Format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, i := range results {
+				if err := w.Write(i); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := b.String(); got != tc.Want {
+				t.Errorf("want %q, got %q", tc.Want, got)
+			}
+		})
+	}
+
+	if _, err := NewWriter(&bytes.Buffer{}, "xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,233 @@
+// Package runner solves many days concurrently, isolating each day's timeouts
+// and panics from the rest
+package runner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/measure"
+	"github.com/xorkevin/advent2021/internal/result"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+var (
+	ErrNoSolver = errors.New("No solver for day")
+	ErrTimeout  = errors.New("Timed out")
+)
+
+type (
+	// Job is a day to solve
+	Job struct {
+		Day int
+		// Part is the part to solve, or 0 for both
+		Part int
+		// Input is the name of the puzzle input, or - for stdin
+		Input string
+	}
+
+	// Outcome is the result of a job, where Results holds the parts which
+	// were solved before any error
+	Outcome struct {
+		Job     Job
+		Results []result.Result
+		Err     error
+	}
+
+	// Options configure a run
+	Options struct {
+		// Workers is the number of days solved at once, where less than 1 is
+		// the number of CPUs. Memory is only measured when it is 1, since the
+		// measurements are of the whole process, and not after a day times out,
+		// since its solver keeps allocating in the background.
+		Workers int
+		// Timeout is the time allowed to solve each day, or 0 for no limit
+		Timeout time.Duration
+	}
+
+	// PanicError is a panic recovered from a solver
+	PanicError struct {
+		Value interface{}
+		Stack []byte
+	}
+)
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+func readInput(name string) (_ []byte, retErr error) {
+	file, err := input.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	return io.ReadAll(file)
+}
+
+// solvePart solves a part, returning a panic as a PanicError
+func solvePart(s solver.Solver, part int, data []byte, measureMem bool) (a solver.Answer, sample measure.Sample, retErr error) {
+	defer func() {
+		if v := recover(); v != nil {
+			buf := make([]byte, 64<<10)
+			buf = buf[:runtime.Stack(buf, false)]
+			retErr = &PanicError{Value: v, Stack: buf}
+		}
+	}()
+	solve := func() error {
+		var err error
+		a, err = s.SolvePart(part, bytes.NewReader(data))
+		return err
+	}
+	if !measureMem {
+		start := time.Now()
+		err := solve()
+		return a, measure.Sample{Elapsed: time.Since(start)}, err
+	}
+	sample, err := measure.Run(solve)
+	return a, sample, err
+}
+
+// Day solves the parts of a job, recovering any panic
+func Day(registry *solver.Registry, job Job, measureMem bool) Outcome {
+	out := Outcome{Job: job}
+	s, ok := registry.Get(job.Day)
+	if !ok {
+		out.Err = fmt.Errorf("%w %d", ErrNoSolver, job.Day)
+		return out
+	}
+	data, err := readInput(job.Input)
+	if err != nil {
+		out.Err = err
+		return out
+	}
+	hash := result.HashInput(data)
+	parts := []int{1, 2}
+	if job.Part != 0 {
+		parts = []int{job.Part}
+	}
+	for _, i := range parts {
+		a, sample, err := solvePart(s, i, data, measureMem)
+		if err != nil {
+			out.Err = input.WithFile(err, job.Input)
+			return out
+		}
+		if !a.Valid() {
+			continue
+		}
+		out.Results = append(out.Results, result.Result{
+			Day:        job.Day,
+			Part:       i,
+			Answer:     a,
+			InputHash:  hash,
+			Elapsed:    sample.Elapsed,
+			Allocs:     sample.Allocs,
+			AllocBytes: sample.AllocBytes,
+			PeakHeap:   sample.PeakHeap,
+			Measured:   measureMem,
+		})
+	}
+	return out
+}
+
+// dayContext solves a job unless ctx is done first. Solvers cannot be
+// interrupted, so a solver which times out keeps running in the background and
+// its outcome is discarded. It returns whether the solver was left running.
+func dayContext(ctx context.Context, registry *solver.Registry, job Job, measureMem bool) (Outcome, bool) {
+	done := make(chan Outcome, 1)
+	go func() {
+		done <- Day(registry, job, measureMem)
+	}()
+	select {
+	case out := <-done:
+		return out, false
+	case <-ctx.Done():
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = ErrTimeout
+		}
+		return Outcome{Job: job, Err: err}, true
+	}
+}
+
+// Run solves the jobs with a pool of workers, and calls fn with each outcome
+// in the order of jobs as soon as it and every job before it are done. Once ctx
+// is done, the jobs not yet started are not solved, and their outcomes hold the
+// error of ctx.
+func Run(ctx context.Context, registry *solver.Registry, jobs []Job, opts Options, fn func(o Outcome)) {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+	measureMem := workers == 1
+	// timedOut is set once a solver has been left running after a timeout
+	var timedOut int32
+
+	outcomes := make([]*Outcome, len(jobs))
+	next := 0
+	var mu sync.Mutex
+	finish := func(n int, o Outcome) {
+		mu.Lock()
+		defer mu.Unlock()
+		outcomes[n] = &o
+		for next < len(outcomes) && outcomes[next] != nil {
+			fn(*outcomes[next])
+			outcomes[next] = nil
+			next++
+		}
+	}
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for n := range queue {
+				if err := ctx.Err(); err != nil {
+					finish(n, Outcome{Job: jobs[n], Err: err})
+					continue
+				}
+				dctx, cancel := ctx, context.CancelFunc(func() {})
+				if opts.Timeout > 0 {
+					dctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+				}
+				o, abandoned := dayContext(dctx, registry, jobs[n], measureMem && atomic.LoadInt32(&timedOut) == 0)
+				if abandoned {
+					atomic.StoreInt32(&timedOut, 1)
+				}
+				cancel()
+				finish(n, o)
+			}
+		}()
+	}
+	n := 0
+dispatch:
+	for ; n < len(jobs); n++ {
+		select {
+		case queue <- n:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(queue)
+	wg.Wait()
+	for ; n < len(jobs); n++ {
+		finish(n, Outcome{Job: jobs[n], Err: ctx.Err()})
+	}
+}
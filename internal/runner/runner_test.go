@@ -0,0 +1,207 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(name, []byte("1\n2\n3\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	count := func(r io.Reader) (solver.Answer, error) {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return solver.Answer{}, err
+		}
+		return solver.Int(strings.Count(string(b), "\n")), nil
+	}
+	block := make(chan struct{})
+	defer close(block)
+	registry := solver.NewRegistry()
+	registry.Register(1, solver.Parts{Part1: count, Part2: count})
+	registry.Register(2, solver.Parts{
+		Part1: count,
+		Part2: func(r io.Reader) (solver.Answer, error) {
+			var k []int
+			return solver.Int(k[3]), nil
+		},
+	})
+	registry.Register(3, solver.Parts{
+		Part1: func(r io.Reader) (solver.Answer, error) {
+			<-block
+			return solver.Int(0), nil
+		},
+	})
+	registry.Register(5, solver.Parts{Part1: count})
+
+	jobs := []Job{
+		{Day: 1, Input: name},
+		{Day: 2, Input: name},
+		{Day: 3, Input: name},
+		{Day: 4, Input: name},
+		{Day: 5, Part: 1, Input: filepath.Join(dir, "missing.txt")},
+		{Day: 5, Part: 1, Input: name},
+	}
+	var outcomes []Outcome
+	Run(context.Background(), registry, jobs, Options{Workers: 3, Timeout: 50 * time.Millisecond}, func(o Outcome) {
+		outcomes = append(outcomes, o)
+	})
+	if len(outcomes) != len(jobs) {
+		t.Fatalf("want %d outcomes, got %d", len(jobs), len(outcomes))
+	}
+	for n, i := range outcomes {
+		if i.Job != jobs[n] {
+			t.Errorf("outcome %d is for job %+v, want %+v", n, i.Job, jobs[n])
+		}
+	}
+
+	if o := outcomes[0]; o.Err != nil || len(o.Results) != 2 || o.Results[1].Answer.String() != "3" {
+		t.Errorf("unexpected outcome for day 1: %+v", o)
+	}
+	var perr *PanicError
+	if o := outcomes[1]; !errors.As(o.Err, &perr) || len(o.Results) != 1 {
+		t.Errorf("want panic after part 1 of day 2, got %+v", o)
+	} else if !strings.Contains(perr.Error(), "index out of range") || len(perr.Stack) == 0 {
+		t.Errorf("unexpected panic error %q", perr)
+	}
+	if o := outcomes[2]; !errors.Is(o.Err, ErrTimeout) {
+		t.Errorf("want timeout for day 3, got %v", o.Err)
+	}
+	if o := outcomes[3]; !errors.Is(o.Err, ErrNoSolver) {
+		t.Errorf("want no solver for day 4, got %v", o.Err)
+	}
+	if o := outcomes[4]; !errors.Is(o.Err, os.ErrNotExist) {
+		t.Errorf("want missing input for day 5, got %v", o.Err)
+	}
+	if o := outcomes[5]; o.Err != nil || len(o.Results) != 1 || o.Results[0].Part != 1 {
+		t.Errorf("unexpected outcome for day 5: %+v", o)
+	}
+}
+
+func TestDayMeasure(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(name, []byte("abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	registry := solver.NewRegistry()
+	registry.Register(1, solver.Parts{Part1: func(r io.Reader) (solver.Answer, error) {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return solver.Answer{}, err
+		}
+		return solver.String(string(b)), nil
+	}})
+	o := Day(registry, Job{Day: 1, Input: name}, true)
+	if o.Err != nil {
+		t.Fatal(o.Err)
+	}
+	if len(o.Results) != 1 {
+		t.Fatalf("want 1 result, got %d", len(o.Results))
+	}
+	r := o.Results[0]
+	if r.Answer.String() != "abc" || r.Allocs == 0 || !r.Measured || r.InputHash == "" {
+		t.Errorf("unexpected result %+v", r)
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(name, []byte("abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var solved []int
+	registry := solver.NewRegistry()
+	for i := 1; i <= 3; i++ {
+		day := i
+		registry.Register(day, solver.Parts{Part1: func(r io.Reader) (solver.Answer, error) {
+			solved = append(solved, day)
+			if day == 1 {
+				cancel()
+			}
+			return solver.Int(day), nil
+		}})
+	}
+	jobs := []Job{
+		{Day: 1, Part: 1, Input: name},
+		{Day: 2, Part: 1, Input: name},
+		{Day: 3, Part: 1, Input: name},
+	}
+	var outcomes []Outcome
+	Run(ctx, registry, jobs, Options{Workers: 1}, func(o Outcome) {
+		outcomes = append(outcomes, o)
+	})
+	if len(outcomes) != len(jobs) {
+		t.Fatalf("want %d outcomes, got %d", len(jobs), len(outcomes))
+	}
+	if len(solved) != 1 || solved[0] != 1 {
+		t.Errorf("want only day 1 solved, got %v", solved)
+	}
+	for n, i := range outcomes[1:] {
+		if i.Job != jobs[n+1] || !errors.Is(i.Err, context.Canceled) {
+			t.Errorf("want canceled outcome for job %+v, got %+v", jobs[n+1], i)
+		}
+	}
+}
+
+func TestRunMeasureAfterTimeout(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(name, []byte("abc"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	read := func(r io.Reader) (solver.Answer, error) {
+		b, err := io.ReadAll(r)
+		if err != nil {
+			return solver.Answer{}, err
+		}
+		return solver.String(string(b)), nil
+	}
+	block := make(chan struct{})
+	defer close(block)
+	registry := solver.NewRegistry()
+	registry.Register(1, solver.Parts{Part1: read})
+	registry.Register(2, solver.Parts{Part1: func(r io.Reader) (solver.Answer, error) {
+		<-block
+		return solver.Int(0), nil
+	}})
+	registry.Register(3, solver.Parts{Part1: read})
+	jobs := []Job{
+		{Day: 1, Part: 1, Input: name},
+		{Day: 2, Part: 1, Input: name},
+		{Day: 3, Part: 1, Input: name},
+	}
+	var outcomes []Outcome
+	Run(context.Background(), registry, jobs, Options{Workers: 1, Timeout: 50 * time.Millisecond}, func(o Outcome) {
+		outcomes = append(outcomes, o)
+	})
+	if len(outcomes) != len(jobs) {
+		t.Fatalf("want %d outcomes, got %d", len(jobs), len(outcomes))
+	}
+	if o := outcomes[0]; o.Err != nil || len(o.Results) != 1 || o.Results[0].Allocs == 0 {
+		t.Errorf("want memory measured for day 1, got %+v", o)
+	}
+	if o := outcomes[1]; !errors.Is(o.Err, ErrTimeout) {
+		t.Errorf("want timeout for day 2, got %v", o.Err)
+	}
+	if o := outcomes[2]; o.Err != nil || len(o.Results) != 1 {
+		t.Errorf("unexpected outcome for day 3: %+v", o)
+	} else if r := o.Results[0]; r.Measured || r.Allocs != 0 || r.AllocBytes != 0 || r.PeakHeap != 0 {
+		t.Errorf("want no memory stats for day 3 after a timeout, got %+v", r)
+	}
+}
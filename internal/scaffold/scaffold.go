@@ -0,0 +1,242 @@
+// Package scaffold generates the directory of a new day from the template in
+// tpl, and registers it with the solver registry and the answers manifest
+package scaffold
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+	"github.com/xorkevin/advent2021/tpl"
+)
+
+const (
+	// Module is the import path of the repository
+	Module = "github.com/xorkevin/advent2021"
+	// RegistryName is the path of the registry source relative to the root
+	RegistryName = "internal/days/days.go"
+
+	tplName = "tpl"
+	// exampleName is the input for the puzzle's worked example, which is
+	// tested by the generated test
+	exampleName = "input2.txt"
+)
+
+var (
+	ErrExists   = errors.New("Day already exists")
+	ErrRegistry = errors.New("Malformed registry")
+)
+
+// rename returns the template file name and contents for the day
+func rename(name string, b []byte, dir string) (string, []byte) {
+	s := string(b)
+	switch {
+	case strings.HasSuffix(name, ".go"):
+		s = strings.Replace(s, "package "+tplName+"\n", "package "+dir+"\n", 1)
+		name = strings.Replace(name, tplName, dir, 1)
+	case name == "Cargo.toml":
+		s = strings.Replace(s, `name = "`+tplName+`"`, `name = "`+dir+`"`, 1)
+	}
+	return name, []byte(s)
+}
+
+// New creates the directory of the day, registers its solver, and adds its
+// example input to the answers manifest, returning the paths written relative
+// to root. Nothing is written if the day already exists in any of them, and
+// everything written is undone if any write fails.
+func New(root string, day int) ([]string, error) {
+	return create(root, day, tpl.Files)
+}
+
+// create is New with the template files in files
+func create(root string, day int, files fs.FS) (_ []string, retErr error) {
+	dir := answers.DirName(day)
+	if _, err := os.Stat(filepath.Join(root, dir)); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrExists, dir)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	registryName := filepath.Join(root, filepath.FromSlash(RegistryName))
+	src, err := os.ReadFile(registryName)
+	if err != nil {
+		return nil, err
+	}
+	registry, err := Register(src, day)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestName := filepath.Join(root, answers.DefaultName)
+	m, err := answers.Load(manifestName)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := m[dir]; ok {
+		return nil, fmt.Errorf("%w: %s in %s", ErrExists, dir, answers.DefaultName)
+	}
+	m[dir] = map[string]answers.Expected{
+		exampleName: {},
+	}
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	manifest = append(manifest, '\n')
+
+	dayDir := filepath.Join(root, dir)
+	if err := os.Mkdir(dayDir, 0755); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			os.RemoveAll(dayDir)
+		}
+	}()
+	var written []string
+	if err := fs.WalkDir(files, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		b, err := fs.ReadFile(files, p)
+		if err != nil {
+			return err
+		}
+		name, b := rename(p, b, dir)
+		name = path.Join(dir, name)
+		if err := writeNew(filepath.Join(root, filepath.FromSlash(name)), b); err != nil {
+			return err
+		}
+		written = append(written, name)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := writeAtomic(registryName, registry); err != nil {
+		return nil, err
+	}
+	written = append(written, RegistryName)
+	if err := writeAtomic(manifestName, manifest); err != nil {
+		if rerr := writeAtomic(registryName, src); rerr != nil {
+			return nil, fmt.Errorf("%w; restoring %s: %v", err, RegistryName, rerr)
+		}
+		return nil, err
+	}
+	written = append(written, answers.DefaultName)
+	return written, nil
+}
+
+// writeAtomic replaces a file by renaming a temporary file over it, so that it
+// is never left partly written
+func writeAtomic(name string, b []byte) (retErr error) {
+	file, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			os.Remove(file.Name())
+		}
+	}()
+	if _, err := file.Write(b); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), name)
+}
+
+// writeNew writes a file which must not already exist
+func writeNew(name string, b []byte) (retErr error) {
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := file.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	_, err = file.Write(b)
+	return err
+}
+
+// Register returns the registry source with the import and registration of
+// the day added in order
+func Register(src []byte, day int) ([]byte, error) {
+	dir := answers.DirName(day)
+	importLine := fmt.Sprintf("\t%q", Module+"/"+dir)
+	registerLine := fmt.Sprintf("\tr.Register(%d, solver.Parts{Part1: %s.Part1, Part2: %s.Part2})", day, dir, dir)
+
+	lines := strings.Split(string(src), "\n")
+	var imports, registers []int
+	for n, i := range lines {
+		switch {
+		case strings.HasPrefix(i, "\t\""+Module+"/day"):
+			imports = append(imports, n)
+		case strings.HasPrefix(i, "\tr.Register("):
+			registers = append(registers, n)
+		}
+	}
+	if len(imports) == 0 || len(registers) == 0 {
+		return nil, fmt.Errorf("%w: no day imports or registrations", ErrRegistry)
+	}
+	for _, i := range imports {
+		if lines[i] == importLine {
+			return nil, fmt.Errorf("%w: %s is registered", ErrExists, dir)
+		}
+	}
+
+	// registrations are inserted first so that the import indices stay valid
+	at := sort.Search(len(registers), func(i int) bool {
+		var k int
+		fmt.Sscanf(lines[registers[i]], "\tr.Register(%d,", &k)
+		return k > day
+	})
+	lines = insertLine(lines, insertIndex(registers, at), registerLine)
+	at = sort.Search(len(imports), func(i int) bool {
+		return lines[imports[i]] > importLine
+	})
+	lines = insertLine(lines, insertIndex(imports, at), importLine)
+
+	b, err := format.Source([]byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRegistry, err)
+	}
+	return b, nil
+}
+
+// insertIndex returns the line to insert at so that the new line comes
+// before the nth matching line, or after the last
+func insertIndex(matches []int, n int) int {
+	if n < len(matches) {
+		return matches[n]
+	}
+	return matches[len(matches)-1] + 1
+}
+
+func insertLine(lines []string, n int, line string) []string {
+	lines = append(lines, "")
+	copy(lines[n+1:], lines[n:])
+	lines[n] = line
+	return lines
+}
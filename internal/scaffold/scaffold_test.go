@@ -0,0 +1,205 @@
+package scaffold
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+)
+
+const (
+	testRegistry = `// Package days registers the solver for every day
+package days
+
+import (
+	"github.com/xorkevin/advent2021/day01"
+	"github.com/xorkevin/advent2021/day03"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+// Registry returns a registry of the solvers for every day
+func Registry() *solver.Registry {
+	r := solver.NewRegistry()
+	r.Register(1, solver.Parts{Part1: day01.Part1, Part2: day01.Part2})
+	r.Register(3, solver.Parts{Part1: day03.Part1})
+	return r
+}
+`
+	testManifest = `{
+  "day01": {
+    "input.txt": {
+      "part1": "1475",
+      "part2": "1516"
+    }
+  }
+}
+`
+)
+
+func writeFile(t *testing.T, name, s string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(name, []byte(s), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func newRoot(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, filepath.FromSlash(RegistryName)), testRegistry)
+	writeFile(t, filepath.Join(root, answers.DefaultName), testManifest)
+	return root
+}
+
+func TestRegister(t *testing.T) {
+	for _, tc := range []struct {
+		day     int
+		imports string
+		reg     string
+	}{
+		{
+			day:     2,
+			imports: "\"github.com/xorkevin/advent2021/day01\"\n\t\"github.com/xorkevin/advent2021/day02\"\n\t\"github.com/xorkevin/advent2021/day03\"\n",
+			reg:     "day01.Part2})\n\tr.Register(2, solver.Parts{Part1: day02.Part1, Part2: day02.Part2})\n\tr.Register(3,",
+		},
+		{
+			day:     25,
+			imports: "\"github.com/xorkevin/advent2021/day03\"\n\t\"github.com/xorkevin/advent2021/day25\"\n\t\"github.com/xorkevin/advent2021/internal/solver\"\n",
+			reg:     "day03.Part1})\n\tr.Register(25, solver.Parts{Part1: day25.Part1, Part2: day25.Part2})\n\treturn r",
+		},
+	} {
+		b, err := Register([]byte(testRegistry), tc.day)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), tc.imports) {
+			t.Errorf("day %d: imports missing %q in:\n%s", tc.day, tc.imports, b)
+		}
+		if !strings.Contains(string(b), tc.reg) {
+			t.Errorf("day %d: registration missing %q in:\n%s", tc.day, tc.reg, b)
+		}
+	}
+
+	if _, err := Register([]byte(testRegistry), 3); !errors.Is(err, ErrExists) {
+		t.Errorf("want ErrExists, got %v", err)
+	}
+	if _, err := Register([]byte("package days\n"), 3); !errors.Is(err, ErrRegistry) {
+		t.Errorf("want ErrRegistry, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	root := newRoot(t)
+	written, err := New(root, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []string{
+		"day02/day02.go",
+		"day02/day02_test.go",
+		"day02/input2.txt",
+		"day02/Makefile",
+		"day02/Cargo.toml",
+		"day02/src/main.rs",
+		RegistryName,
+		answers.DefaultName,
+	} {
+		found := false
+		for _, j := range written {
+			if i == j {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("%s not in written files %v", i, written)
+		}
+	}
+	if s := readFile(t, filepath.Join(root, "day02", "day02.go")); !strings.HasPrefix(s, "package day02\n") {
+		t.Errorf("solver has wrong package:\n%s", s)
+	}
+	if s := readFile(t, filepath.Join(root, "day02", "day02_test.go")); !strings.HasPrefix(s, "package day02\n") {
+		t.Errorf("test has wrong package:\n%s", s)
+	}
+	if s := readFile(t, filepath.Join(root, "day02", "Cargo.toml")); !strings.Contains(s, `name = "day02"`) {
+		t.Errorf("crate has wrong name:\n%s", s)
+	}
+	if s := readFile(t, filepath.Join(root, filepath.FromSlash(RegistryName))); !strings.Contains(s, "day02.Part1") {
+		t.Errorf("day not registered:\n%s", s)
+	}
+	m, err := answers.Load(filepath.Join(root, answers.DefaultName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["day02"]["input2.txt"]; !ok {
+		t.Errorf("no manifest entry for example input: %v", m)
+	}
+	if m["day01"]["input.txt"].Part2 != "1516" {
+		t.Errorf("existing manifest entry changed: %v", m)
+	}
+	for _, i := range []string{root, filepath.Dir(filepath.Join(root, filepath.FromSlash(RegistryName)))} {
+		if tmp, _ := filepath.Glob(filepath.Join(i, ".*")); len(tmp) != 0 {
+			t.Errorf("temporary files left behind: %v", tmp)
+		}
+	}
+
+	// an existing day is never overwritten
+	writeFile(t, filepath.Join(root, "day02", "day02.go"), "package day02\n// edited\n")
+	if _, err := New(root, 2); !errors.Is(err, ErrExists) {
+		t.Errorf("want ErrExists, got %v", err)
+	}
+	if s := readFile(t, filepath.Join(root, "day02", "day02.go")); !strings.Contains(s, "edited") {
+		t.Error("existing day was overwritten")
+	}
+}
+
+func TestNewRegistered(t *testing.T) {
+	root := newRoot(t)
+	// day03 is registered but has no directory
+	if _, err := New(root, 3); !errors.Is(err, ErrExists) {
+		t.Errorf("want ErrExists, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "day03")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("day directory was created: %v", err)
+	}
+	if s := readFile(t, filepath.Join(root, answers.DefaultName)); s != testManifest {
+		t.Errorf("manifest was changed:\n%s", s)
+	}
+}
+
+func TestNewRollback(t *testing.T) {
+	root := newRoot(t)
+	// tpl.go is renamed to day02.go, which is already written
+	files := fstest.MapFS{
+		"day02.go": {Data: []byte("package tpl\n")},
+		"tpl.go":   {Data: []byte("package tpl\n")},
+	}
+	if _, err := create(root, 2, files); !errors.Is(err, os.ErrExist) {
+		t.Errorf("want os.ErrExist, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "day02")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("day directory was not removed: %v", err)
+	}
+	if s := readFile(t, filepath.Join(root, filepath.FromSlash(RegistryName))); s != testRegistry {
+		t.Errorf("registry was changed:\n%s", s)
+	}
+	if s := readFile(t, filepath.Join(root, answers.DefaultName)); s != testManifest {
+		t.Errorf("manifest was changed:\n%s", s)
+	}
+}
@@ -0,0 +1,324 @@
+// Package search finds least cost paths through weighted graphs
+package search
+
+import (
+	"container/heap"
+)
+
+type (
+	// Edge is a weighted edge to a node
+	Edge[N comparable] struct {
+		To   N
+		Cost int
+	}
+
+	// Graph is a weighted directed graph given by functions which return the
+	// edges of a node
+	Graph[N comparable] struct {
+		// Neighbors returns the edges leaving n
+		Neighbors func(n N) []Edge[N]
+		// Predecessors returns the edges entering n, where To is the node the
+		// edge leaves from. It is only needed by Bidirectional, and may be nil
+		// for undirected graphs where it is the same as Neighbors.
+		Predecessors func(n N) []Edge[N]
+	}
+
+	// Stats counts the work done by a search
+	Stats struct {
+		// Expanded is the number of nodes whose edges were visited
+		Expanded int
+		// Pushed is the number of nodes added to the open set
+		Pushed int
+		// Updated is the number of times a shorter path to an open node was
+		// found
+		Updated int
+		// MaxOpen is the largest size of the open set
+		MaxOpen int
+	}
+
+	// Result is the outcome of a search
+	Result[N comparable] struct {
+		// Found is whether a goal was reached
+		Found bool
+		// Cost is the total cost of Path, or -1 if no goal was reached
+		Cost int
+		// Path is the nodes from the start to the goal inclusive
+		Path  []N
+		Stats Stats
+	}
+)
+
+type (
+	item[N comparable] struct {
+		value N
+		g, f  int
+		index int
+	}
+
+	openSet[N comparable] struct {
+		q []*item[N]
+		s map[N]*item[N]
+	}
+)
+
+func newOpenSet[N comparable]() *openSet[N] {
+	return &openSet[N]{
+		s: map[N]*item[N]{},
+	}
+}
+
+func (q openSet[N]) Len() int { return len(q.q) }
+func (q openSet[N]) Less(i, j int) bool {
+	return q.q[i].f < q.q[j].f
+}
+func (q openSet[N]) Swap(i, j int) {
+	q.q[i], q.q[j] = q.q[j], q.q[i]
+	q.q[i].index = i
+	q.q[j].index = j
+}
+func (q *openSet[N]) Push(x interface{}) {
+	item := x.(*item[N])
+	item.index = len(q.q)
+	q.q = append(q.q, item)
+	q.s[item.value] = item
+}
+func (q *openSet[N]) Pop() interface{} {
+	n := len(q.q)
+	item := q.q[n-1]
+	q.q[n-1] = nil  // avoid memory leak
+	item.index = -1 // for safety
+	q.q = q.q[:n-1]
+	delete(q.s, item.value)
+	return item
+}
+
+func (q *openSet[N]) get(value N) (*item[N], bool) {
+	item, ok := q.s[value]
+	return item, ok
+}
+
+func (q *openSet[N]) push(value N, g, f int) {
+	heap.Push(q, &item[N]{
+		value: value,
+		g:     g,
+		f:     f,
+	})
+}
+
+func (q *openSet[N]) pop() *item[N] {
+	return heap.Pop(q).(*item[N])
+}
+
+func (q *openSet[N]) peek() *item[N] {
+	return q.q[0]
+}
+
+func (q *openSet[N]) update(it *item[N], g, f int) {
+	it.g = g
+	it.f = f
+	heap.Fix(q, it.index)
+}
+
+func reconstruct[N comparable](parents map[N]N, end N) []N {
+	path := []N{end}
+	for {
+		p, ok := parents[path[len(path)-1]]
+		if !ok {
+			break
+		}
+		path = append(path, p)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+func notFound[N comparable](stats Stats) Result[N] {
+	return Result[N]{
+		Cost:  -1,
+		Stats: stats,
+	}
+}
+
+// Dijkstra returns a least cost path from start to the first node for which
+// isGoal returns true
+func (g Graph[N]) Dijkstra(start N, isGoal func(n N) bool) Result[N] {
+	return g.AStar(start, isGoal, nil)
+}
+
+// AStar returns a least cost path from start to the first node for which
+// isGoal returns true, guided by a heuristic which must never overestimate
+// the remaining cost and must be consistent. A nil heuristic is Dijkstra's
+// algorithm.
+func (g Graph[N]) AStar(start N, isGoal func(n N) bool, heuristic func(n N) int) Result[N] {
+	h := func(n N) int {
+		if heuristic == nil {
+			return 0
+		}
+		return heuristic(n)
+	}
+
+	var stats Stats
+	open := newOpenSet[N]()
+	open.push(start, 0, h(start))
+	stats.Pushed++
+	closed := map[N]struct{}{}
+	parents := map[N]N{}
+	for open.Len() > 0 {
+		if open.Len() > stats.MaxOpen {
+			stats.MaxOpen = open.Len()
+		}
+		cur := open.pop()
+		closed[cur.value] = struct{}{}
+		if isGoal(cur.value) {
+			return Result[N]{
+				Found: true,
+				Cost:  cur.g,
+				Path:  reconstruct(parents, cur.value),
+				Stats: stats,
+			}
+		}
+		stats.Expanded++
+		for _, e := range g.Neighbors(cur.value) {
+			if _, ok := closed[e.To]; ok {
+				continue
+			}
+			gs := cur.g + e.Cost
+			if v, ok := open.get(e.To); ok {
+				if gs < v.g {
+					open.update(v, gs, gs+h(e.To))
+					parents[e.To] = cur.value
+					stats.Updated++
+				}
+				continue
+			}
+			open.push(e.To, gs, gs+h(e.To))
+			parents[e.To] = cur.value
+			stats.Pushed++
+		}
+	}
+	return notFound[N](stats)
+}
+
+type (
+	frontier[N comparable] struct {
+		edges   func(n N) []Edge[N]
+		open    *openSet[N]
+		closed  map[N]int
+		parents map[N]N
+	}
+)
+
+func newFrontier[N comparable](start N, edges func(n N) []Edge[N]) *frontier[N] {
+	f := &frontier[N]{
+		edges:   edges,
+		open:    newOpenSet[N](),
+		closed:  map[N]int{},
+		parents: map[N]N{},
+	}
+	f.open.push(start, 0, 0)
+	return f
+}
+
+// dist returns the best known cost to n from this frontier's start
+func (f *frontier[N]) dist(n N) (int, bool) {
+	if d, ok := f.closed[n]; ok {
+		return d, true
+	}
+	if it, ok := f.open.get(n); ok {
+		return it.g, true
+	}
+	return 0, false
+}
+
+// expand settles the closest open node, and returns each node whose cost
+// was lowered
+func (f *frontier[N]) expand(stats *Stats) []N {
+	cur := f.open.pop()
+	f.closed[cur.value] = cur.g
+	stats.Expanded++
+	changed := []N{cur.value}
+	for _, e := range f.edges(cur.value) {
+		if _, ok := f.closed[e.To]; ok {
+			continue
+		}
+		gs := cur.g + e.Cost
+		if v, ok := f.open.get(e.To); ok {
+			if gs < v.g {
+				f.open.update(v, gs, gs)
+				f.parents[e.To] = cur.value
+				stats.Updated++
+				changed = append(changed, e.To)
+			}
+			continue
+		}
+		f.open.push(e.To, gs, gs)
+		f.parents[e.To] = cur.value
+		stats.Pushed++
+		changed = append(changed, e.To)
+	}
+	return changed
+}
+
+// Bidirectional returns a least cost path from start to goal by running
+// Dijkstra's algorithm forwards from start and backwards from goal until the
+// two searches meet
+func (g Graph[N]) Bidirectional(start, goal N) Result[N] {
+	if start == goal {
+		return Result[N]{
+			Found: true,
+			Cost:  0,
+			Path:  []N{start},
+		}
+	}
+	pred := g.Predecessors
+	if pred == nil {
+		pred = g.Neighbors
+	}
+
+	var stats Stats
+	fwd := newFrontier(start, g.Neighbors)
+	bwd := newFrontier(goal, pred)
+	stats.Pushed += 2
+	best := -1
+	var meet N
+	for fwd.open.Len() > 0 && bwd.open.Len() > 0 {
+		if k := fwd.open.Len() + bwd.open.Len(); k > stats.MaxOpen {
+			stats.MaxOpen = k
+		}
+		if best >= 0 && fwd.open.peek().g+bwd.open.peek().g >= best {
+			break
+		}
+		cur, other := fwd, bwd
+		if bwd.open.Len() < fwd.open.Len() {
+			cur, other = bwd, fwd
+		}
+		for _, i := range cur.expand(&stats) {
+			d1, _ := cur.dist(i)
+			d2, ok := other.dist(i)
+			if !ok {
+				continue
+			}
+			if best < 0 || d1+d2 < best {
+				best = d1 + d2
+				meet = i
+			}
+		}
+	}
+	if best < 0 {
+		return notFound[N](stats)
+	}
+
+	path := reconstruct(fwd.parents, meet)
+	back := reconstruct(bwd.parents, meet)
+	for i := len(back) - 2; i >= 0; i-- {
+		path = append(path, back[i])
+	}
+	return Result[N]{
+		Found: true,
+		Cost:  best,
+		Path:  path,
+		Stats: stats,
+	}
+}
@@ -0,0 +1,148 @@
+package search
+
+import (
+	"testing"
+)
+
+type (
+	point struct {
+		x, y int
+	}
+)
+
+var (
+	riskGrid = []string{
+		"1163751742",
+		"1381373672",
+		"2136511328",
+		"3694931569",
+		"7463417111",
+		"1319128137",
+		"1359912421",
+		"3125421639",
+		"1293138521",
+		"2311944581",
+	}
+)
+
+func (p point) neighbors() []point {
+	var k []point
+	for _, d := range []point{{-1, 0}, {0, -1}, {1, 0}, {0, 1}} {
+		n := point{p.x + d.x, p.y + d.y}
+		if n.x < 0 || n.y < 0 || n.y >= len(riskGrid) || n.x >= len(riskGrid[n.y]) {
+			continue
+		}
+		k = append(k, n)
+	}
+	return k
+}
+
+func risk(p point) int {
+	return int(riskGrid[p.y][p.x] - '0')
+}
+
+// riskGraph costs the risk of the cell being entered, so its edges differ
+// in each direction
+func riskGraph() Graph[point] {
+	return Graph[point]{
+		Neighbors: func(p point) []Edge[point] {
+			var edges []Edge[point]
+			for _, i := range p.neighbors() {
+				edges = append(edges, Edge[point]{To: i, Cost: risk(i)})
+			}
+			return edges
+		},
+		Predecessors: func(p point) []Edge[point] {
+			var edges []Edge[point]
+			for _, i := range p.neighbors() {
+				edges = append(edges, Edge[point]{To: i, Cost: risk(p)})
+			}
+			return edges
+		},
+	}
+}
+
+func pathCost(t *testing.T, path []point, start, end point) int {
+	t.Helper()
+	if len(path) == 0 || path[0] != start || path[len(path)-1] != end {
+		t.Fatalf("path %v does not run from %v to %v", path, start, end)
+	}
+	k := 0
+	for n := 1; n < len(path); n++ {
+		a, b := path[n-1], path[n]
+		if d := abs(a.x-b.x) + abs(a.y-b.y); d != 1 {
+			t.Fatalf("path steps from %v to %v", a, b)
+		}
+		k += risk(b)
+	}
+	return k
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+func TestSearch(t *testing.T) {
+	g := riskGraph()
+	start := point{0, 0}
+	end := point{9, 9}
+	isEnd := func(p point) bool {
+		return p == end
+	}
+	manhattan := func(p point) int {
+		return abs(p.x-end.x) + abs(p.y-end.y)
+	}
+	for _, tc := range []struct {
+		name string
+		res  Result[point]
+	}{
+		{"dijkstra", g.Dijkstra(start, isEnd)},
+		{"astar", g.AStar(start, isEnd, manhattan)},
+		{"bidirectional", g.Bidirectional(start, end)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.res.Found {
+				t.Fatal("path not found")
+			}
+			if tc.res.Cost != 40 {
+				t.Errorf("want cost 40, got %d", tc.res.Cost)
+			}
+			if k := pathCost(t, tc.res.Path, start, end); k != tc.res.Cost {
+				t.Errorf("path costs %d, result has %d", k, tc.res.Cost)
+			}
+			if tc.res.Stats.Expanded == 0 || tc.res.Stats.Pushed == 0 || tc.res.Stats.MaxOpen == 0 {
+				t.Errorf("missing stats %+v", tc.res.Stats)
+			}
+		})
+	}
+
+	dijkstra := g.Dijkstra(start, isEnd)
+	astar := g.AStar(start, isEnd, manhattan)
+	if astar.Stats.Expanded > dijkstra.Stats.Expanded {
+		t.Errorf("astar expanded %d nodes, more than dijkstra %d", astar.Stats.Expanded, dijkstra.Stats.Expanded)
+	}
+}
+
+func TestSearchNotFound(t *testing.T) {
+	g := Graph[int]{
+		Neighbors: func(n int) []Edge[int] {
+			if n >= 3 {
+				return nil
+			}
+			return []Edge[int]{{To: n + 1, Cost: 1}}
+		},
+	}
+	if res := g.Dijkstra(0, func(n int) bool { return n == 5 }); res.Found || res.Cost != -1 || res.Path != nil {
+		t.Errorf("want not found, got %+v", res)
+	}
+	if res := g.Bidirectional(0, 5); res.Found || res.Cost != -1 {
+		t.Errorf("want not found, got %+v", res)
+	}
+	res := g.Bidirectional(2, 2)
+	if !res.Found || res.Cost != 0 || len(res.Path) != 1 {
+		t.Errorf("want empty path to self, got %+v", res)
+	}
+}
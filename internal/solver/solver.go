@@ -0,0 +1,215 @@
+// Package solver defines the common interface implemented by each day's
+// puzzle solver
+package solver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	answerKindNone = iota
+	answerKindInt
+	answerKindString
+	answerKindGrid
+)
+
+type (
+	// Answer is the solution to one part of a puzzle
+	Answer struct {
+		kind int
+		num  int
+		str  string
+		rows []string
+	}
+)
+
+// Int returns an integer answer
+func Int(n int) Answer {
+	return Answer{
+		kind: answerKindInt,
+		num:  n,
+	}
+}
+
+// String returns a string answer which may span multiple lines
+func String(s string) Answer {
+	return Answer{
+		kind: answerKindString,
+		str:  s,
+	}
+}
+
+// Grid returns an answer drawn as rows of glyphs, which must be read by eye
+func Grid(rows []string) Answer {
+	return Answer{
+		kind: answerKindGrid,
+		rows: rows,
+	}
+}
+
+// Valid returns whether the answer is present, since some days have no
+// second part
+func (a Answer) Valid() bool {
+	return a.kind != answerKindNone
+}
+
+// Int returns the value of an integer answer
+func (a Answer) Int() (int, bool) {
+	if a.kind != answerKindInt {
+		return 0, false
+	}
+	return a.num, true
+}
+
+// Rows returns the rows of a grid answer
+func (a Answer) Rows() ([]string, bool) {
+	if a.kind != answerKindGrid {
+		return nil, false
+	}
+	return a.rows, true
+}
+
+// Kind returns the name of the kind of answer, one of "int", "string",
+// "grid", or "" if absent
+func (a Answer) Kind() string {
+	switch a.kind {
+	case answerKindInt:
+		return "int"
+	case answerKindString:
+		return "string"
+	case answerKindGrid:
+		return "grid"
+	default:
+		return ""
+	}
+}
+
+func (a Answer) String() string {
+	switch a.kind {
+	case answerKindInt:
+		return strconv.Itoa(a.num)
+	case answerKindString:
+		return a.str
+	case answerKindGrid:
+		return strings.Join(a.rows, "\n")
+	default:
+		return ""
+	}
+}
+
+// MarshalJSON encodes an int answer as a number, a string answer as a string,
+// a grid answer as an array of rows, and an absent answer as null
+func (a Answer) MarshalJSON() ([]byte, error) {
+	switch a.kind {
+	case answerKindInt:
+		return json.Marshal(a.num)
+	case answerKindString:
+		return json.Marshal(a.str)
+	case answerKindGrid:
+		return json.Marshal(a.rows)
+	default:
+		return []byte("null"), nil
+	}
+}
+
+type (
+	// Solver solves a day's puzzle given its input
+	Solver interface {
+		// Solve solves both parts
+		Solve(r io.Reader) (Answer, Answer, error)
+		// SolvePart solves only the numbered part
+		SolvePart(part int, r io.Reader) (Answer, error)
+	}
+
+	// Part solves a single part of a puzzle
+	Part func(r io.Reader) (Answer, error)
+
+	// Parts is a Solver whose parts are solved independently of each other,
+	// where a nil part has no answer
+	Parts struct {
+		Part1 Part
+		Part2 Part
+	}
+)
+
+var (
+	// ErrInvalidPart is returned when solving a part other than 1 or 2
+	ErrInvalidPart = errors.New("Invalid part")
+)
+
+// Solve implements Solver by reading all of r and solving each part with its
+// own copy of the input
+func (p Parts) Solve(r io.Reader) (Answer, Answer, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return Answer{}, Answer{}, err
+	}
+	part1, err := p.SolvePart(1, bytes.NewReader(b))
+	if err != nil {
+		return Answer{}, Answer{}, err
+	}
+	part2, err := p.SolvePart(2, bytes.NewReader(b))
+	if err != nil {
+		return Answer{}, Answer{}, err
+	}
+	return part1, part2, nil
+}
+
+// SolvePart implements Solver
+func (p Parts) SolvePart(part int, r io.Reader) (Answer, error) {
+	var fn Part
+	switch part {
+	case 1:
+		fn = p.Part1
+	case 2:
+		fn = p.Part2
+	default:
+		return Answer{}, fmt.Errorf("%w: %d", ErrInvalidPart, part)
+	}
+	if fn == nil {
+		return Answer{}, nil
+	}
+	return fn(r)
+}
+
+type (
+	// Registry maps days to their solvers
+	Registry struct {
+		solvers map[int]Solver
+	}
+)
+
+// NewRegistry creates a new empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		solvers: map[int]Solver{},
+	}
+}
+
+// Register adds the solver for a day, replacing any existing one
+func (r *Registry) Register(day int, s Solver) {
+	r.solvers[day] = s
+}
+
+// Get returns the solver for a day
+func (r *Registry) Get(day int) (Solver, bool) {
+	s, ok := r.solvers[day]
+	return s, ok
+}
+
+// Days returns all registered days in ascending order
+func (r *Registry) Days() []int {
+	days := make([]int, 0, len(r.solvers))
+	for k := range r.solvers {
+		days = append(days, k)
+	}
+	sort.Ints(days)
+	return days
+}
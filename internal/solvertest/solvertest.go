@@ -0,0 +1,124 @@
+// Package solvertest provides helpers for testing day solvers
+package solvertest
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xorkevin/advent2021/internal/answers"
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+type (
+	// Case is a puzzle input and its expected answers, where an empty answer
+	// is one that is absent
+	Case struct {
+		// Name is an input file relative to the package directory, or the name
+		// of the case if Text is set
+		Name string
+		// Text is the puzzle input, used instead of reading the file Name
+		Text  string
+		Part1 string
+		Part2 string
+	}
+)
+
+func (c Case) open() (io.ReadCloser, error) {
+	if c.Text != "" {
+		return io.NopCloser(strings.NewReader(c.Text)), nil
+	}
+	return os.Open(c.Name)
+}
+
+// Run runs the solver on each case as a subtest
+func Run(t *testing.T, s solver.Solver, cases []Case) {
+	t.Helper()
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			file, err := tc.open()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				if err := file.Close(); err != nil {
+					t.Error(err)
+				}
+			}()
+			part1, part2, err := s.Solve(file)
+			if err != nil {
+				t.Fatal(input.WithFile(err, tc.Name))
+			}
+			if got := part1.String(); got != tc.Part1 {
+				t.Errorf("Part 1: want %q, got %q", tc.Part1, got)
+			}
+			if got := part2.String(); got != tc.Part2 {
+				t.Errorf("Part 2: want %q, got %q", tc.Part2, got)
+			}
+		})
+	}
+}
+
+// Manifest runs the solver as a subtest on each input of the package's day in
+// the answers manifest at the repository root, so that the answers to bundled
+// inputs are only recorded there. It is skipped if the day has no inputs in the
+// manifest, as for the template.
+func Manifest(t *testing.T, s solver.Solver) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Base(wd)
+	root := filepath.Dir(wd)
+	m, err := answers.Load(filepath.Join(root, answers.DefaultName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m[dir]) == 0 {
+		t.Skipf("no inputs for %s in %s", dir, answers.DefaultName)
+	}
+	for _, e := range m.Entries() {
+		if e.Dir != dir {
+			continue
+		}
+		e := e
+		t.Run(e.Input, func(t *testing.T) {
+			mismatches, err := answers.Check(s, root, e)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, i := range mismatches {
+				t.Error(i)
+			}
+		})
+	}
+}
+
+// Bench benchmarks a single part on the input file name, which is read into
+// memory once so that only solving is measured. It is skipped if the input does
+// not exist, as for a new day whose input has not been fetched.
+func Bench(b *testing.B, fn solver.Part, name string) {
+	b.Helper()
+	data, err := os.ReadFile(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			b.Skipf("%s not found", name)
+		}
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := fn(bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
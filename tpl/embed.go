@@ -0,0 +1,12 @@
+// Package tpl is the template that new days are generated from
+package tpl
+
+import (
+	"embed"
+)
+
+// Files are the template files, where package and crate names of tpl are
+// replaced by the name of the new day
+//
+//go:embed tpl.go tpl_test.go input2.txt Makefile Cargo.toml src/main.rs
+var Files embed.FS
@@ -0,0 +1,16 @@
+use std::fs::File;
+use std::io::prelude::*;
+use std::io::BufReader;
+
+const PUZZLEINPUT: &str = "input.txt";
+
+fn main() -> Result<(), Box<dyn std::error::Error>> {
+    let file = File::open(PUZZLEINPUT)?;
+    let reader = BufReader::new(file);
+
+    let _lines = reader.lines().collect::<Result<Vec<String>, _>>()?;
+
+    println!("Part 1:");
+    println!("Part 2:");
+    Ok(())
+}
@@ -0,0 +1,26 @@
+package tpl
+
+import (
+	"io"
+
+	"github.com/xorkevin/advent2021/internal/input"
+	"github.com/xorkevin/advent2021/internal/solver"
+)
+
+func parse(r io.Reader) ([]string, error) {
+	return input.Lines(r)
+}
+
+func Part1(r io.Reader) (solver.Answer, error) {
+	if _, err := parse(r); err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Answer{}, nil
+}
+
+func Part2(r io.Reader) (solver.Answer, error) {
+	if _, err := parse(r); err != nil {
+		return solver.Answer{}, err
+	}
+	return solver.Answer{}, nil
+}